@@ -0,0 +1,12 @@
+data "samsungcloudplatform_block_storage_snapshots" "my_scp_block_storage_snapshots" {
+  block_storage_id = var.block_storage_id
+  sort             = ["createdDt:desc"]
+}
+
+output "output_my_scp_block_storage_snapshots" {
+  value = data.samsungcloudplatform_block_storage_snapshots.my_scp_block_storage_snapshots
+}
+
+variable "block_storage_id" {
+  default = ""
+}
@@ -0,0 +1,7 @@
+data "samsungcloudplatform_bm_block_storage_snapshot_capacity" "my_scp_bm_block_storage_snapshot_capacity" {
+  storage_id = "STORAGE-xxxxxxxxxxxxxxxxxxxxx"
+}
+
+output "output_my_scp_bm_block_storage_snapshot_usage_rate" {
+  value = data.samsungcloudplatform_bm_block_storage_snapshot_capacity.my_scp_bm_block_storage_snapshot_capacity.usage_rate
+}
@@ -0,0 +1,16 @@
+# Find all certificates
+data "samsungcloudplatform_certificates" "my_scp_certificates" {
+}
+
+# Find certificates expiring within 30 days
+data "samsungcloudplatform_certificates" "my_scp_expiring_certificates" {
+  expires_within_days = 30
+}
+
+output "output_scp_certificates" {
+  value = data.samsungcloudplatform_certificates.my_scp_certificates
+}
+
+output "output_scp_expiring_certificates" {
+  value = data.samsungcloudplatform_certificates.my_scp_expiring_certificates.contents
+}
@@ -0,0 +1,15 @@
+data "terraform_remote_state" "dns_domain" {
+  backend = "local"
+
+  config = {
+    path = "../../resources/scp_dns_domain/terraform.tfstate"
+  }
+}
+
+data "samsungcloudplatform_dns_zone_file" "my_scp_dns_zone_file" {
+  dns_domain_id = data.terraform_remote_state.dns_domain.outputs.id
+}
+
+output "zone_file" {
+  value = data.samsungcloudplatform_dns_zone_file.my_scp_dns_zone_file.zone_file
+}
@@ -0,0 +1,7 @@
+data "samsungcloudplatform_hpc_lite_new_resource_pools" "my_hpc_lite_new_resource_pools" {
+  service_zone_id = "ZONE-xxxxxxxxxxxxxxxxxxxxx"
+}
+
+output "output_my_hpc_lite_new_resource_pools" {
+  value = data.samsungcloudplatform_hpc_lite_new_resource_pools.my_hpc_lite_new_resource_pools.contents
+}
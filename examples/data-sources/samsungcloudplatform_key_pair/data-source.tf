@@ -0,0 +1,8 @@
+# Find the key pair by name
+data "samsungcloudplatform_key_pair" "my_scp_key_pair" {
+  key_pair_name = "terraform-keypair"
+}
+
+output "output_scp_key_pair_fingerprint" {
+  value = data.samsungcloudplatform_key_pair.my_scp_key_pair.fingerprint
+}
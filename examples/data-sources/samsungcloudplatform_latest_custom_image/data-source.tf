@@ -0,0 +1,14 @@
+data "samsungcloudplatform_region" "region" {
+}
+
+data "samsungcloudplatform_latest_custom_image" "my_scp_latest_custom_image" {
+  region     = data.samsungcloudplatform_region.region.location
+  name_regex = "^web-image"
+  tags = {
+    env = "prod"
+  }
+}
+
+output "output_my_scp_latest_custom_image" {
+  value = data.samsungcloudplatform_latest_custom_image.my_scp_latest_custom_image.image_id
+}
@@ -0,0 +1,15 @@
+# Check whether web server can reach database server on port 5432
+data "samsungcloudplatform_network_path_analysis" "web_to_db" {
+  source_virtual_server_id      = "SERVER-xxxxxx"
+  destination_virtual_server_id = "SERVER-yyyyyy"
+  protocol                      = "TCP"
+  port                          = 5432
+}
+
+output "output_web_to_db_allowed" {
+  value = data.samsungcloudplatform_network_path_analysis.web_to_db.allowed
+}
+
+output "output_web_to_db_hops" {
+  value = data.samsungcloudplatform_network_path_analysis.web_to_db.hops
+}
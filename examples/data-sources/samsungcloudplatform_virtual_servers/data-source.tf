@@ -15,3 +15,15 @@ output "output_my_virtual_servers" {
 output "output_my_asg_virtual_servers" {
   value = data.samsungcloudplatform_virtual_servers.my_asg_virtual_servers
 }
+
+data "samsungcloudplatform_virtual_servers" "my_prod_virtual_servers" {
+  virtual_server_state = "RUNNING"
+  subnet_id            = "SUBNET-XXXXXXXXXXXXXXXXXXXXXX"
+  tags = {
+    env = "prod"
+  }
+}
+
+output "output_my_prod_virtual_server_ips" {
+  value = [for server in data.samsungcloudplatform_virtual_servers.my_prod_virtual_servers.contents : server.nics[*].ip]
+}
@@ -0,0 +1,16 @@
+resource "samsungcloudplatform_block_storage" "my_shared_bs" {
+  name              = var.name
+  storage_size_gb   = var.size
+  encrypt_enable    = false
+  product_name      = "SSD"
+  shared_type       = "SHARED"
+  virtual_server_id = var.virtual_server_id
+}
+
+resource "samsungcloudplatform_block_storage_attachment" "my_bs_attachment" {
+  for_each = toset(var.additional_virtual_server_ids)
+
+  block_storage_id   = samsungcloudplatform_block_storage.my_shared_bs.id
+  virtual_server_id  = each.value
+  stop_before_detach = true
+}
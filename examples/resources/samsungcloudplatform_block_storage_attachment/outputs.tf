@@ -0,0 +1,3 @@
+output "ids" {
+  value = [for attachment in samsungcloudplatform_block_storage_attachment.my_bs_attachment : attachment.id]
+}
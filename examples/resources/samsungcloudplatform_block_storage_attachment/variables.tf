@@ -0,0 +1,16 @@
+variable "name" {
+  default = "bssharedtest"
+}
+
+variable "size" {
+  default = "10"
+}
+
+variable "virtual_server_id" {
+  default = ""
+}
+
+variable "additional_virtual_server_ids" {
+  type    = list(string)
+  default = []
+}
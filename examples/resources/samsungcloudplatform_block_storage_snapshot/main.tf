@@ -0,0 +1,16 @@
+resource "samsungcloudplatform_block_storage_snapshot" "my_bs_snapshot" {
+  block_storage_id = data.terraform_remote_state.bs.outputs.id
+  name             = var.name
+  description      = "before upgrade"
+}
+
+resource "samsungcloudplatform_block_storage" "my_restored_bs" {
+  name            = var.restored_name
+  storage_size_gb = samsungcloudplatform_block_storage_snapshot.my_bs_snapshot.snapshot_size_gb
+  encrypt_enable  = samsungcloudplatform_block_storage_snapshot.my_bs_snapshot.encrypt_enabled
+  product_name    = "SSD"
+  shared_type     = "DEDICATED"
+
+  source_snapshot_id = samsungcloudplatform_block_storage_snapshot.my_bs_snapshot.id
+  virtual_server_id  = data.terraform_remote_state.vm.outputs.id
+}
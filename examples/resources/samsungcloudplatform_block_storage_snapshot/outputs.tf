@@ -0,0 +1,7 @@
+output "id" {
+  value = samsungcloudplatform_block_storage_snapshot.my_bs_snapshot.id
+}
+
+output "restored_id" {
+  value = samsungcloudplatform_block_storage.my_restored_bs.id
+}
@@ -0,0 +1,37 @@
+data "samsungcloudplatform_region" "region" {
+}
+
+data "samsungcloudplatform_standard_images" "centos_image" {
+  service_group = "COMPUTE"
+  service       = "Baremetal Server"
+  region        = data.samsungcloudplatform_region.region.location
+  filter {
+    name   = "image_name"
+    values = ["CentOS 7.8 *"]
+    use_regex = true
+  }
+}
+
+resource "samsungcloudplatform_bm_server_node" "nodes" {
+  for_each = var.nodes
+
+  bm_server_name    = each.key
+  ipv4              = each.value.ipv4
+  nat_enabled       = each.value.nat_enabled
+  state             = each.value.state
+  admin_account     = var.id
+  admin_password    = var.password
+  cpu_count         = var.cpu
+  memory_size_gb    = var.memory
+  image_id          = data.samsungcloudplatform_standard_images.centos_image.standard_images[0].id
+  vpc_id            = data.terraform_remote_state.vpc.outputs.id
+  subnet_id         = data.terraform_remote_state.subnet.outputs.id
+  delete_protection = false
+  contract_discount = "None"
+
+  timeouts {
+    create = "30m"
+    update = "30m"
+    delete = "30m"
+  }
+}
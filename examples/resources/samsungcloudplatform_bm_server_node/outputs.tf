@@ -0,0 +1,3 @@
+output "ids" {
+  value = { for name, node in samsungcloudplatform_bm_server_node.nodes : name => node.id }
+}
@@ -0,0 +1,51 @@
+data "terraform_remote_state" "vpc" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_vpc/terraform.tfstate"
+  }
+}
+
+data "terraform_remote_state" "subnet" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_subnet/terraform.tfstate"
+  }
+}
+
+variable "id" {
+  default = "root"
+}
+
+variable "password" {
+  default = ""
+}
+
+variable "cpu" {
+  default = 8
+}
+
+variable "memory" {
+  default = 32
+}
+
+variable "nodes" {
+  type = map(object({
+    ipv4        = string
+    nat_enabled = bool
+    state       = string
+  }))
+  default = {
+    "terrabm1" = {
+      ipv4        = "192.168.29.40"
+      nat_enabled = true
+      state       = "RUNNING"
+    }
+    "terrabm2" = {
+      ipv4        = "192.168.29.41"
+      nat_enabled = false
+      state       = "RUNNING"
+    }
+  }
+}
@@ -0,0 +1,7 @@
+resource "samsungcloudplatform_certificate" "my_certificate" {
+  certificate_name  = var.name
+  certificate_body  = file(var.certificate_file)
+  certificate_chain = file(var.certificate_chain_file)
+  private_key       = file(var.private_key_file)
+  min_valid_days    = 30
+}
@@ -0,0 +1,7 @@
+output "id" {
+  value = samsungcloudplatform_certificate.my_certificate.id
+}
+
+output "not_after" {
+  value = samsungcloudplatform_certificate.my_certificate.not_after
+}
@@ -0,0 +1,15 @@
+variable "name" {
+  default = "www-example-com"
+}
+
+variable "certificate_file" {
+  default = "./certs/www.example.com.crt"
+}
+
+variable "certificate_chain_file" {
+  default = "./certs/chain.crt"
+}
+
+variable "private_key_file" {
+  default = "./certs/www.example.com.key"
+}
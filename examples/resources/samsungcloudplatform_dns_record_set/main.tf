@@ -0,0 +1,29 @@
+resource "samsungcloudplatform_dns_record_set" "my_dns_record_set" {
+  dns_domain_id = data.terraform_remote_state.dns_domain.outputs.id
+
+  record {
+    dns_record_name = "@"
+    dns_record_type = "A"
+    ttl             = 300
+    dns_record_mapping {
+      record_destination = "192.168.0.1"
+    }
+    dns_record_mapping {
+      record_destination = "192.168.0.2"
+    }
+  }
+
+  record {
+    dns_record_name = var.mail_record_name
+    dns_record_type = "MX"
+    ttl             = 600
+    dns_record_mapping {
+      record_destination = "192.168.0.10"
+      preference         = 10
+    }
+    dns_record_mapping {
+      record_destination = "192.168.0.20"
+      preference         = 20
+    }
+  }
+}
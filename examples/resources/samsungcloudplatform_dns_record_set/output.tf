@@ -0,0 +1,3 @@
+output "record_ids" {
+  value = samsungcloudplatform_dns_record_set.my_dns_record_set.record_ids
+}
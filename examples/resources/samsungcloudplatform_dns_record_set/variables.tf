@@ -0,0 +1,11 @@
+data "terraform_remote_state" "dns_domain" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_dns_domain/terraform.tfstate"
+  }
+}
+
+variable "mail_record_name" {
+  default = "mail"
+}
@@ -0,0 +1,5 @@
+resource "samsungcloudplatform_dns_records_from_zone_file" "my_dns_records" {
+  dns_domain_id = data.terraform_remote_state.dns_domain.outputs.id
+  zone_file     = file(var.zone_file_path)
+  authoritative = false
+}
@@ -0,0 +1,3 @@
+output "record_ids" {
+  value = samsungcloudplatform_dns_records_from_zone_file.my_dns_records.record_ids
+}
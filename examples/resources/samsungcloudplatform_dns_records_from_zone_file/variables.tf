@@ -0,0 +1,11 @@
+data "terraform_remote_state" "dns_domain" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_dns_domain/terraform.tfstate"
+  }
+}
+
+variable "zone_file_path" {
+  default = "./example.zone"
+}
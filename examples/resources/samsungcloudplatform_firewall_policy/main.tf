@@ -0,0 +1,27 @@
+resource "samsungcloudplatform_firewall_policy" "my_policy" {
+  firewall_id = var.firewall_id
+
+  # Rules are evaluated in the listed order
+  rule {
+    direction                  = "IN"
+    action                     = "ALLOW"
+    source_addresses_ipv4      = ["10.0.0.0/16"]
+    destination_addresses_ipv4 = ["192.168.0.10"]
+    service {
+      type  = "TCP"
+      value = "443"
+    }
+    description = "Allow https from internal network"
+  }
+
+  rule {
+    direction                  = "IN"
+    action                     = "DROP"
+    source_addresses_ipv4      = ["0.0.0.0/0"]
+    destination_addresses_ipv4 = ["192.168.0.10"]
+    service {
+      type = "ALL"
+    }
+    description = "Drop everything else"
+  }
+}
@@ -0,0 +1,3 @@
+output "rule_ids" {
+  value = samsungcloudplatform_firewall_policy.my_policy.rule[*].id
+}
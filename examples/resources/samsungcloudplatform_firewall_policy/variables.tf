@@ -0,0 +1,3 @@
+variable "firewall_id" {
+  default = "FIREWALL-xxxxxxx"
+}
@@ -45,8 +45,15 @@ resource "samsungcloudplatform_gslb" "my_gslb" {
 #  service_port = 80
 #  gslb_health_check_user_id = "tester"
 #  gslb_health_check_user_password = "test123$%"
-#  gslb_send_string = "GET /index.html"
-#  gslb_response_string= "test response"
+#  health_check {
+#    method = "GET"
+#    path   = "/healthz"
+#    headers = {
+#      Host = "www.example.com"
+#    }
+#    expected_status_codes = [200, 204]
+#    expected_body_regex   = "OK"
+#  }
 #  gslb_resources  {
 #    gslb_destination            = "192.168.0.1"
 #    gslb_region    = "KR-WEST-1"
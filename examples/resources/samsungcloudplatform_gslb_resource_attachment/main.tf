@@ -0,0 +1,8 @@
+resource "samsungcloudplatform_gslb_resource_attachment" "my_gslb_resource" {
+  gslb_id                   = var.gslb_id
+  gslb_destination          = "192.168.0.4"
+  gslb_region               = "KR-WEST-2"
+  gslb_resource_weight      = 10
+  gslb_resource_disable     = false
+  gslb_resource_description = "regional endpoint"
+}
@@ -0,0 +1,3 @@
+output "id" {
+  value = samsungcloudplatform_gslb_resource_attachment.my_gslb_resource.id
+}
@@ -0,0 +1,3 @@
+variable "gslb_id" {
+  default = ""
+}
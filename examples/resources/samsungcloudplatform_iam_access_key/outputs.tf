@@ -1,3 +1,7 @@
 output "id" {
   value = samsungcloudplatform_iam_access_key.my_access_key1.id
 }
+
+output "encrypted_secret" {
+  value = samsungcloudplatform_iam_access_key.my_access_key1.encrypted_secret
+}
@@ -5,3 +5,7 @@ variable "project_id" {
 variable "duration_days" {
   default = 90
 }
+
+variable "pgp_key" {
+  default = "keybase:terraform"
+}
@@ -0,0 +1,33 @@
+data "samsungcloudplatform_region" "region" {
+}
+
+data "samsungcloudplatform_standard_image" "centos_image" {
+  service_group = "COMPUTE"
+  service       = "Virtual Server"
+  region        = data.samsungcloudplatform_region.region.location
+  filter {
+    name   = "image_name"
+    values = ["CentOS 7.8"]
+  }
+}
+
+resource "samsungcloudplatform_image_builder" "image_001" {
+  image_name        = var.name
+  image_description = var.desc
+
+  base_image_id       = data.samsungcloudplatform_standard_image.centos_image.id
+  server_type         = var.server-type
+  provisioning_script = file("${path.module}/provision.sh")
+
+  vpc_id    = data.terraform_remote_state.vpc.outputs.id
+  subnet_id = data.terraform_remote_state.subnet.outputs.id
+  security_group_ids = [
+    data.terraform_remote_state.security_group.outputs.id
+  ]
+  nat_enabled = true
+  key_pair_id = data.terraform_remote_state.key_pair.outputs.id
+
+  triggers = {
+    version = var.image-version
+  }
+}
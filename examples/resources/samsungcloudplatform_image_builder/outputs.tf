@@ -0,0 +1,7 @@
+output "id" {
+  value = samsungcloudplatform_image_builder.image_001.id
+}
+
+output "build_inputs_hash" {
+  value = samsungcloudplatform_image_builder.image_001.build_inputs_hash
+}
@@ -0,0 +1,47 @@
+data "terraform_remote_state" "vpc" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_vpc/terraform.tfstate"
+  }
+}
+
+data "terraform_remote_state" "subnet" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_subnet/terraform.tfstate"
+  }
+}
+
+data "terraform_remote_state" "security_group" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_security_group/terraform.tfstate"
+  }
+}
+
+data "terraform_remote_state" "key_pair" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_key_pair/terraform.tfstate"
+  }
+}
+
+variable "name" {
+  default = "web-image"
+}
+
+variable "desc" {
+  default = "nginx image"
+}
+
+variable "server-type" {
+  default = "s1v1m2"
+}
+
+variable "image-version" {
+  default = "1"
+}
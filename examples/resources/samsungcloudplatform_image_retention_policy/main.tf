@@ -0,0 +1,13 @@
+data "samsungcloudplatform_region" "region" {
+}
+
+resource "samsungcloudplatform_image_retention_policy" "policy_001" {
+  region     = data.samsungcloudplatform_region.region.location
+  name_regex = var.name-regex
+  image_tags = {
+    env = "prod"
+  }
+
+  keep_versions = var.keep-versions
+  max_age_days  = var.max-age-days
+}
@@ -0,0 +1,3 @@
+output "deleted_image_ids" {
+  value = samsungcloudplatform_image_retention_policy.policy_001.deleted_image_ids
+}
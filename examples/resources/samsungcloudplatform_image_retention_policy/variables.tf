@@ -0,0 +1,11 @@
+variable "name-regex" {
+  default = "^web-image"
+}
+
+variable "keep-versions" {
+  default = 3
+}
+
+variable "max-age-days" {
+  default = 90
+}
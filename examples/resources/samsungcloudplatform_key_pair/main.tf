@@ -1,3 +1,13 @@
 resource "samsungcloudplatform_key_pair" "my_keypair" {
   key_pair_name = var.key-pair-name
 }
+
+resource "samsungcloudplatform_key_pair" "my_encrypted_keypair" {
+  key_pair_name = var.encrypted-key-pair-name
+  pgp_key       = var.pgp-key
+}
+
+resource "samsungcloudplatform_key_pair" "my_imported_keypair" {
+  key_pair_name = var.imported-key-pair-name
+  public_key    = file(var.public-key-path)
+}
@@ -1,3 +1,11 @@
 output "id" {
   value = samsungcloudplatform_key_pair.my_keypair.id
 }
+
+output "encrypted_private_key" {
+  value = samsungcloudplatform_key_pair.my_encrypted_keypair.encrypted_private_key
+}
+
+output "imported_fingerprint" {
+  value = samsungcloudplatform_key_pair.my_imported_keypair.fingerprint
+}
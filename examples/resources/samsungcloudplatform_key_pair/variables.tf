@@ -6,3 +6,23 @@ variable "key-pair-name" {
   type = string
   default = "terraform-keypair"
 }
+
+variable "encrypted-key-pair-name" {
+  type = string
+  default = "terraform-keypair-pgp"
+}
+
+variable "pgp-key" {
+  type = string
+  default = "keybase:terraform"
+}
+
+variable "imported-key-pair-name" {
+  type = string
+  default = "terraform-keypair-byok"
+}
+
+variable "public-key-path" {
+  type = string
+  default = "~/.ssh/id_rsa.pub"
+}
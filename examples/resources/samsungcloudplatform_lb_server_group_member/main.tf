@@ -0,0 +1,19 @@
+resource "samsungcloudplatform_lb_server_group_member" "my_lb_server_group_member" {
+  lb_id              = data.terraform_remote_state.load_balancer.outputs.id
+  lb_server_group_id = data.terraform_remote_state.lb_server_group.outputs.group_http_id
+  object_type        = "INSTANCE"
+  object_id          = data.terraform_remote_state.virtual_server.outputs.id
+  object_port        = 80
+  weight             = 1
+  join_state         = "ENABLED"
+}
+
+resource "samsungcloudplatform_lb_server_group_member" "my_lb_server_group_manual_member" {
+  lb_id              = data.terraform_remote_state.load_balancer.outputs.id
+  lb_server_group_id = data.terraform_remote_state.lb_server_group.outputs.group_http_id
+  object_type        = "MANUAL"
+  object_ip_address  = "192.168.1.10"
+  object_port        = 8080
+  weight             = 2
+  join_state         = "ENABLED"
+}
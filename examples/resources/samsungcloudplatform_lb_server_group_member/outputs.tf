@@ -0,0 +1,3 @@
+output "id" {
+  value = samsungcloudplatform_lb_server_group_member.my_lb_server_group_member.id
+}
@@ -0,0 +1,23 @@
+data "terraform_remote_state" "load_balancer" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_load_balancer/terraform.tfstate"
+  }
+}
+
+data "terraform_remote_state" "lb_server_group" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_lb_server_group/terraform.tfstate"
+  }
+}
+
+data "terraform_remote_state" "virtual_server" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_virtual_server/terraform.tfstate"
+  }
+}
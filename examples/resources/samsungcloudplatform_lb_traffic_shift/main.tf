@@ -0,0 +1,9 @@
+resource "samsungcloudplatform_lb_traffic_shift" "my_lb_traffic_shift" {
+  lb_id                 = data.terraform_remote_state.load_balancer.outputs.id
+  blue_server_group_id  = data.terraform_remote_state.lb_server_group.outputs.group_http_id
+  green_server_group_id = data.terraform_remote_state.lb_server_group.outputs.group_tcp_id
+  green_weight          = var.green_weight
+  step_weight           = 10
+  step_interval_sec     = 60
+  rollback_on_failure   = true
+}
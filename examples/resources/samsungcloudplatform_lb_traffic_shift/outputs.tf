@@ -0,0 +1,7 @@
+output "id" {
+  value = samsungcloudplatform_lb_traffic_shift.my_lb_traffic_shift.id
+}
+
+output "green_weight" {
+  value = samsungcloudplatform_lb_traffic_shift.my_lb_traffic_shift.green_weight
+}
@@ -0,0 +1,19 @@
+data "terraform_remote_state" "load_balancer" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_load_balancer/terraform.tfstate"
+  }
+}
+
+data "terraform_remote_state" "lb_server_group" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_lb_server_group/terraform.tfstate"
+  }
+}
+
+variable "green_weight" {
+  default = 30
+}
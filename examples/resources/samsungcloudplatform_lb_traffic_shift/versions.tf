@@ -0,0 +1,14 @@
+
+terraform {
+  required_providers {
+    samsungcloudplatform = {
+      version = "3.13.0"
+      source  = "SamsungSDSCloud/samsungcloudplatform"
+    }
+  }
+  required_version = ">= 0.13"
+}
+
+# Provider setup
+provider "samsungcloudplatform" {
+}
@@ -13,3 +13,20 @@ resource "samsungcloudplatform_security_group_rule" "tc_my_rule" {
     value = "80"
   }
 }
+
+# Allow traffic from the virtual servers of another security group and a named ip set
+resource "samsungcloudplatform_security_group_rule" "tc_my_reference_rule" {
+  security_group_id         = data.terraform_remote_state.security-group.outputs.id
+  direction                 = "in"
+  description               = "Allow from web tier"
+  remote_security_group_ids = [var.web_security_group_id]
+  ip_set {
+    name               = "bastion"
+    virtual_server_ids = [var.bastion_virtual_server_id]
+    addresses_ipv4     = ["10.10.0.0/24"]
+  }
+  service {
+    type  = "tcp"
+    value = "5432"
+  }
+}
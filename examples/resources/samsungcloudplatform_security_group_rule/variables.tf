@@ -5,3 +5,11 @@ data "terraform_remote_state" "security-group" {
     path = "../samsungcloudplatform_security_group/terraform.tfstate"
   }
 }
+
+variable "web_security_group_id" {
+  default = "FIREWALL_SECURITY_GROUP-xxxxxx"
+}
+
+variable "bastion_virtual_server_id" {
+  default = "SERVER-xxxxxx"
+}
@@ -0,0 +1,5 @@
+resource "samsungcloudplatform_virtual_server_nic" "nic_001" {
+  virtual_server_id = data.terraform_remote_state.virtual_server.outputs.id
+  subnet_id         = data.terraform_remote_state.local_subnet.outputs.id
+  ipv4              = var.ipv4
+}
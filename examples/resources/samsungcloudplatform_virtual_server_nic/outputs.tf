@@ -0,0 +1,3 @@
+output "id" {
+  value = samsungcloudplatform_virtual_server_nic.nic_001.id
+}
@@ -0,0 +1,19 @@
+data "terraform_remote_state" "virtual_server" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_virtual_server/terraform.tfstate"
+  }
+}
+
+data "terraform_remote_state" "local_subnet" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_subnet/terraform.tfstate"
+  }
+}
+
+variable "ipv4" {
+  default = "192.168.10.20"
+}
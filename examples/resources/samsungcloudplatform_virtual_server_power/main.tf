@@ -0,0 +1,4 @@
+resource "samsungcloudplatform_virtual_server_power" "my_vm_power" {
+  virtual_server_id = data.terraform_remote_state.vm.outputs.id
+  state             = var.state
+}
@@ -0,0 +1,3 @@
+output "state" {
+  value = samsungcloudplatform_virtual_server_power.my_vm_power.state
+}
@@ -0,0 +1,11 @@
+data "terraform_remote_state" "vm" {
+  backend = "local"
+
+  config = {
+    path = "../samsungcloudplatform_virtual_server/terraform.tfstate"
+  }
+}
+
+variable "state" {
+  default = "STOPPED"
+}
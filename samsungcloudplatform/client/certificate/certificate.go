@@ -0,0 +1,69 @@
+package certificate
+
+import (
+	"context"
+
+	sdk "github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/client"
+	certificatemanager "github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/certificate-manager"
+	"github.com/antihax/optional"
+)
+
+type Client struct {
+	config    *sdk.Configuration
+	sdkClient *certificatemanager.APIClient
+}
+
+func NewClient(config *sdk.Configuration) *Client {
+	return &Client{
+		config:    config,
+		sdkClient: certificatemanager.NewAPIClient(config),
+	}
+}
+
+func (client *Client) CreateCertificate(ctx context.Context, request CreateRequest) (certificatemanager.CertificateDetailResponse, int, error) {
+	result, c, err := client.sdkClient.CertificateManagerOpenApiControllerApi.CreateCertificate(ctx, client.config.ProjectId, certificatemanager.CertificateCreateRequest{
+		CertificateName:  request.CertificateName,
+		CertificateBody:  request.CertificateBody,
+		CertificateChain: request.CertificateChain,
+		PrivateKey:       request.PrivateKey,
+		Tags:             client.sdkClient.ToTagRequestList(request.Tags),
+	})
+	var statusCode int
+	if c != nil {
+		statusCode = c.StatusCode
+	}
+	return result, statusCode, err
+}
+
+func (client *Client) GetCertificate(ctx context.Context, certificateId string) (certificatemanager.CertificateDetailResponse, int, error) {
+	result, c, err := client.sdkClient.CertificateManagerOpenApiControllerApi.DetailCertificate(ctx, client.config.ProjectId, certificateId)
+	var statusCode int
+	if c != nil {
+		statusCode = c.StatusCode
+	}
+	return result, statusCode, err
+}
+
+func (client *Client) DeleteCertificate(ctx context.Context, certificateId string) (int, error) {
+	c, err := client.sdkClient.CertificateManagerOpenApiControllerApi.DeleteCertificate(ctx, client.config.ProjectId, certificateId)
+	var statusCode int
+	if c != nil {
+		statusCode = c.StatusCode
+	}
+	return statusCode, err
+}
+
+func (client *Client) ListCertificates(ctx context.Context, request ListCertificatesRequestParam) (certificatemanager.ListResponseCertificateResponse, int, error) {
+	result, c, err := client.sdkClient.CertificateManagerOpenApiControllerApi.ListCertificates(ctx, client.config.ProjectId, &certificatemanager.CertificateManagerOpenApiControllerApiListCertificatesOpts{
+		CertificateName: optional.NewString(request.CertificateName),
+		CommonName:      optional.NewString(request.CommonName),
+		Page:            optional.NewInt32(request.Page),
+		Size:            optional.NewInt32(request.Size),
+		Sort:            optional.NewInterface(request.Sort),
+	})
+	var statusCode int
+	if c != nil {
+		statusCode = c.StatusCode
+	}
+	return result, statusCode, err
+}
@@ -0,0 +1,17 @@
+package certificate
+
+type CreateRequest struct {
+	CertificateName  string
+	CertificateBody  string
+	CertificateChain string
+	PrivateKey       string
+	Tags             map[string]interface{}
+}
+
+type ListCertificatesRequestParam struct {
+	CertificateName string
+	CommonName      string
+	Page            int32
+	Size            int32
+	Sort            string
+}
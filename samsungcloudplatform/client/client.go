@@ -6,6 +6,7 @@ import (
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/autoscaling"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/baremetal"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/baremetalvdc"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/certificate"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/database/epas"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/database/mariadb"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/database/mysql"
@@ -117,6 +118,7 @@ type SCPClient struct {
 
 	Loggingaudit *loggingaudit.Client
 	Tag          *tag.Client
+	Certificate  *certificate.Client
 
 	// Config
 	config *Config
@@ -245,6 +247,7 @@ func NewSCPClient(providerConfig *Config) (*SCPClient, error) {
 
 		Loggingaudit: loggingaudit.NewClient(NewDefaultConfig(providerConfig, "logging-audit")),
 		Tag:          tag.NewClient(NewDefaultConfig(providerConfig, "tag")),
+		Certificate:  certificate.NewClient(NewDefaultConfig(providerConfig, "certificate-manager")),
 
 		// Config
 		config: providerConfig,
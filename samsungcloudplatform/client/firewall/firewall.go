@@ -98,6 +98,18 @@ func (client *Client) GetFirewallRule(ctx context.Context, firewallId string, ru
 	return result, statusCode, err
 }
 
+func (client *Client) ListFirewallRules(ctx context.Context, firewallId string) (firewall2.ListResponseFirewallRuleListItemResponse, int, error) {
+	result, c, err := client.sdkClient.FirewallRuleV2Api.ListFirewallRulesV2(ctx, client.config.ProjectId, firewallId, &firewall2.FirewallRuleV2ApiListFirewallRulesV2Opts{
+		Page: optional.NewInt32(0),
+		Size: optional.NewInt32(10000),
+	})
+	var statusCode int
+	if c != nil {
+		statusCode = c.StatusCode
+	}
+	return result, statusCode, err
+}
+
 func (client *Client) UpdateFirewallRule(ctx context.Context, firewallId string, ruleId string, request firewall2.FirewallRuleUpdateRequest) (firewall2.AsyncResponse, int, error) {
 	result, c, err := client.sdkClient.FirewallRuleV2Api.UpdateFirewallRuleV2(ctx, client.config.ProjectId, firewallId, ruleId, request)
 	var statusCode int
@@ -63,6 +63,51 @@ func (client *Client) DeleteHpcLiteNew(ctx context.Context, request HpcLiteNewDe
 	return result, statusCode, err
 }
 
+func (client *Client) StartHpcLiteNew(ctx context.Context, request HpcLiteNewStartStopRequest) (hpclitenew.AsyncListResponse, int, error) {
+	result, c, err := client.sdkClient.HpcLitePlusOpenAPIV1ControllerApi.StartHpcLitePlusV1(ctx, client.config.ProjectId, hpclitenew.HpcLitePlusOpenApiStartStopRequestVo{
+		ServerIds:     request.ServerIds,
+		ServiceZoneId: request.ServiceZoneId,
+	})
+
+	statusCode := getStatusCode(c)
+	return result, statusCode, err
+}
+
+func (client *Client) StopHpcLiteNew(ctx context.Context, request HpcLiteNewStartStopRequest) (hpclitenew.AsyncListResponse, int, error) {
+	result, c, err := client.sdkClient.HpcLitePlusOpenAPIV1ControllerApi.StopHpcLitePlusV1(ctx, client.config.ProjectId, hpclitenew.HpcLitePlusOpenApiStartStopRequestVo{
+		ServerIds:     request.ServerIds,
+		ServiceZoneId: request.ServiceZoneId,
+	})
+
+	statusCode := getStatusCode(c)
+	return result, statusCode, err
+}
+
+func (client *Client) UpdateHpcLiteNewContract(ctx context.Context, request HpcLiteNewContractUpdateRequest) (hpclitenew.AsyncListResponse, int, error) {
+	result, c, err := client.sdkClient.HpcLitePlusOpenAPIV1ControllerApi.UpdateHpcLitePlusContractV1(ctx, client.config.ProjectId, hpclitenew.HpcLitePlusOpenApiContractUpdateRequestVo{
+		Contract:      request.Contract,
+		ServerIds:     request.ServerIds,
+		ServiceZoneId: request.ServiceZoneId,
+	})
+
+	statusCode := getStatusCode(c)
+	return result, statusCode, err
+}
+
+func (client *Client) GetHpcLiteNewResourcePoolList(ctx context.Context, serviceZoneId string) (hpclitenew.ListResponseHpcLitePlusResourcePoolResponseDto, int, error) {
+	result, c, err := client.sdkClient.HpcLitePlusOpenAPIV1ControllerApi.ListHpcLitePlusResourcePoolsV1(ctx, client.config.ProjectId, serviceZoneId)
+
+	statusCode := getStatusCode(c)
+	return result, statusCode, err
+}
+
+func (client *Client) GetHpcLiteNewVlanPoolList(ctx context.Context, resourcePoolId string) (hpclitenew.ListResponseHpcLitePlusVlanPoolResponseDto, int, error) {
+	result, c, err := client.sdkClient.HpcLitePlusOpenAPIV1ControllerApi.ListHpcLitePlusVlanPoolsV1(ctx, client.config.ProjectId, resourcePoolId)
+
+	statusCode := getStatusCode(c)
+	return result, statusCode, err
+}
+
 func toServerDetailsVoList(serverDetailList []ServerDetailRequest) []hpclitenew.ServerDetailRequestVo {
 	var ret = []hpclitenew.ServerDetailRequestVo{}
 	for _, v := range serverDetailList {
@@ -5,6 +5,12 @@ type CreateRequest struct {
 	Tags        map[string]interface{}
 }
 
+type ImportRequest struct {
+	KeyPairName string
+	PublicKey   string
+	Tags        map[string]interface{}
+}
+
 type TagRequest struct {
 	TagKey   string
 	TagValue string
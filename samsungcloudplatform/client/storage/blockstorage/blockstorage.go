@@ -28,6 +28,7 @@ func (client *Client) CreateBlockStorage(ctx context.Context, request CreateBloc
 		DiskType:         request.DiskType,
 		SharedType:       request.SharedType,
 		VirtualServerId:  request.VirtualServerId,
+		SnapshotId:       request.SnapshotId,
 		Tags:             client.sdkClient.ToTagRequestList(tags),
 	}
 
@@ -118,3 +119,39 @@ func (client *Client) ListBlockStorageVirtualServers(ctx context.Context, blockS
 	})
 	return result, err
 }
+
+func (client *Client) CreateBlockStorageSnapshot(ctx context.Context, blockStorageId string, request CreateBlockStorageSnapshotRequest) (blockstorage2.AsyncResponse, error) {
+	result, _, err := client.sdkClient.BlockStorageSnapshotControllerApi.CreateBlockStorageSnapshot(ctx,
+		client.config.ProjectId,
+		blockStorageId,
+		blockstorage2.BlockStorageSnapshotCreateRequest{
+			SnapshotName:        request.SnapshotName,
+			SnapshotDescription: request.SnapshotDescription,
+		})
+	return result, err
+}
+
+func (client *Client) ReadBlockStorageSnapshot(ctx context.Context, snapshotId string) (blockstorage2.BlockStorageSnapshotResponse, int, error) {
+	result, c, err := client.sdkClient.BlockStorageSnapshotControllerApi.DetailBlockStorageSnapshot(ctx, client.config.ProjectId, snapshotId)
+	var statusCode int
+	if c != nil {
+		statusCode = c.StatusCode
+	}
+	return result, statusCode, err
+}
+
+func (client *Client) ListBlockStorageSnapshots(ctx context.Context, request ListBlockStorageSnapshotsRequest) (blockstorage2.ListResponseBlockStorageSnapshotResponse, error) {
+	result, _, err := client.sdkClient.BlockStorageSnapshotControllerApi.ListBlockStorageSnapshots(ctx, client.config.ProjectId, &blockstorage2.BlockStorageSnapshotControllerApiListBlockStorageSnapshotsOpts{
+		BlockStorageId: optional.NewString(request.BlockStorageId),
+		SnapshotName:   optional.NewString(request.SnapshotName),
+		Page:           optional.NewInt32(request.Page),
+		Size:           optional.NewInt32(request.Size),
+		Sort:           optional.NewInterface(request.Sort),
+	})
+	return result, err
+}
+
+func (client *Client) DeleteBlockStorageSnapshot(ctx context.Context, snapshotId string) (blockstorage2.AsyncResponse, error) {
+	result, _, err := client.sdkClient.BlockStorageSnapshotControllerApi.DeleteBlockStorageSnapshot(ctx, client.config.ProjectId, snapshotId)
+	return result, err
+}
@@ -8,6 +8,7 @@ type CreateBlockStorageRequest struct {
 	SharedType       string
 	Tags             []TagRequest
 	VirtualServerId  string
+	SnapshotId       string
 }
 
 type TagRequest struct {
@@ -43,3 +44,16 @@ type BlockStorageVirtualServersRequest struct {
 	Size int32
 	Sort []string
 }
+
+type CreateBlockStorageSnapshotRequest struct {
+	SnapshotName        string
+	SnapshotDescription string
+}
+
+type ListBlockStorageSnapshotsRequest struct {
+	BlockStorageId string
+	SnapshotName   string
+	Page           int32
+	Size           int32
+	Sort           []string
+}
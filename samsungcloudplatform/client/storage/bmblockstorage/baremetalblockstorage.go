@@ -87,6 +87,17 @@ func (client *Client) DetachBareMetalBlockStorage(ctx context.Context, storageId
 	return result, statusCode, err
 }
 
+func (client *Client) ResizeBareMetalBlockStorage(ctx context.Context, storageId string, storageSize int32) (baremetalblockstorage.AsyncResponse, int, error) {
+	result, c, err := client.sdkClient.BmBlockStorageControllerApi.ResizeBareMetalBlockStorage(ctx, client.config.ProjectId, storageId, baremetalblockstorage.BmBlockStorageResizeRequest{
+		BareMetalBlockStorageSize: &storageSize,
+	})
+	var statusCode int
+	if c != nil {
+		statusCode = c.StatusCode
+	}
+	return result, statusCode, err
+}
+
 func (client *Client) DeleteBareMetalBlockStorage(ctx context.Context, storageId string) (baremetalblockstorage.AsyncResponse, int, error) {
 	result, c, err := client.sdkClient.BmBlockStorageControllerApi.TerminatedBareMetalBlockStorage(ctx, client.config.ProjectId, storageId)
 	var statusCode int
@@ -0,0 +1,170 @@
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	PgpKeybasePrefix string = "keybase:"
+
+	// PgpKeyringEnvName overrides the local keyring file used to resolve "keybase:" references
+	PgpKeyringEnvName string = "SCP_PGP_KEYRING"
+)
+
+func PgpKeySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Either a base64 encoded or ASCII armored PGP public key, or a keybase:<name> reference resolved from the local keyring file ($SCP_PGP_KEYRING or ~/.gnupg/pubring.gpg). The secret is encrypted with the key before it is written to state.",
+	}
+}
+
+func defaultPgpKeyringPath() string {
+	if path := os.Getenv(PgpKeyringEnvName); len(path) != 0 {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".gnupg", "pubring.gpg")
+}
+
+func readPgpKeyRing(data []byte) (openpgp.EntityList, error) {
+	if bytes.Contains(data, []byte("-----BEGIN PGP")) {
+		return openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(data))
+}
+
+// findPgpEntity finds the entity whose identity name, email or key id matches the given name
+func findPgpEntity(entities openpgp.EntityList, name string) *openpgp.Entity {
+	name = strings.ToLower(name)
+	for _, entity := range entities {
+		if strings.ToLower(entity.PrimaryKey.KeyIdString()) == name || strings.ToLower(entity.PrimaryKey.KeyIdShortString()) == name {
+			return entity
+		}
+		for _, identity := range entity.Identities {
+			if identity.UserId == nil {
+				continue
+			}
+			if strings.ToLower(identity.UserId.Name) == name || strings.ToLower(identity.UserId.Email) == name ||
+				strings.HasPrefix(strings.ToLower(identity.UserId.Email), name+"@") {
+				return entity
+			}
+		}
+	}
+	return nil
+}
+
+// RetrievePgpEntity parses the pgp_key argument.
+// The key can be a "keybase:<name>" reference, an ASCII armored public key or a base64 encoded public key.
+func RetrievePgpEntity(pgpKey string, keyringPath string) (*openpgp.Entity, error) {
+	pgpKey = strings.TrimSpace(pgpKey)
+	if strings.HasPrefix(pgpKey, PgpKeybasePrefix) {
+		name := strings.TrimPrefix(pgpKey, PgpKeybasePrefix)
+		if len(keyringPath) == 0 {
+			keyringPath = defaultPgpKeyringPath()
+		}
+		data, err := os.ReadFile(keyringPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pgp keyring file %s : %w", keyringPath, err)
+		}
+		entities, err := readPgpKeyRing(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pgp keyring file %s : %w", keyringPath, err)
+		}
+		entity := findPgpEntity(entities, name)
+		if entity == nil {
+			return nil, fmt.Errorf("pgp key for %s not found in keyring file %s", name, keyringPath)
+		}
+		return entity, nil
+	}
+
+	var data []byte
+	if strings.HasPrefix(pgpKey, "-----BEGIN PGP") {
+		data = []byte(pgpKey)
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(pgpKey)
+		if err != nil {
+			return nil, fmt.Errorf("pgp key must be an ASCII armored or base64 encoded public key : %w", err)
+		}
+		data = decoded
+	}
+
+	entities, err := readPgpKeyRing(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pgp key : %w", err)
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("pgp key must contain exactly one public key, but %d found", len(entities))
+	}
+	return entities[0], nil
+}
+
+// PreparePgpEntity parses the pgp_key argument and checks that the key can be used for encryption.
+// Resources call it before the secret is created, so an unusable key does not leave a secret which can never be recovered.
+func PreparePgpEntity(pgpKey string) (*openpgp.Entity, error) {
+	entity, err := RetrievePgpEntity(pgpKey, "")
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err = EncryptWithPgpEntity(entity, ""); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// ValidatePgpKeyDiff checks the pgp_key argument on plan
+func ValidatePgpKeyDiff(diff *schema.ResourceDiff) error {
+	if !diff.NewValueKnown("pgp_key") {
+		return nil
+	}
+	pgpKey := diff.Get("pgp_key").(string)
+	if len(pgpKey) == 0 {
+		return nil
+	}
+	if _, err := PreparePgpEntity(pgpKey); err != nil {
+		return fmt.Errorf("invalid pgp_key : %w", err)
+	}
+	return nil
+}
+
+// EncryptWithPgpEntity encrypts the value with the resolved pgp key.
+// Returns the hex encoded fingerprint of the key and the base64 encoded encrypted value.
+func EncryptWithPgpEntity(entity *openpgp.Entity, value string) (string, string, error) {
+	buffer := new(bytes.Buffer)
+	writer, err := openpgp.Encrypt(buffer, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt with pgp key : %w", err)
+	}
+	if _, err = writer.Write([]byte(value)); err != nil {
+		return "", "", fmt.Errorf("failed to encrypt with pgp key : %w", err)
+	}
+	if err = writer.Close(); err != nil {
+		return "", "", fmt.Errorf("failed to encrypt with pgp key : %w", err)
+	}
+
+	fingerprint := hex.EncodeToString(entity.PrimaryKey.Fingerprint[:])
+	return fingerprint, base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
+
+// EncryptWithPgpKey encrypts the value with the pgp_key argument.
+// Returns the hex encoded fingerprint of the key and the base64 encoded encrypted value.
+func EncryptWithPgpKey(pgpKey string, value string) (string, string, error) {
+	entity, err := RetrievePgpEntity(pgpKey, "")
+	if err != nil {
+		return "", "", err
+	}
+	return EncryptWithPgpEntity(entity, value)
+}
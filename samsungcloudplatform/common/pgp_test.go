@@ -0,0 +1,119 @@
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+func armoredPublicKey(t *testing.T, entity *openpgp.Entity) string {
+	buffer := new(bytes.Buffer)
+	writer, err := armor.Encode(buffer, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = entity.Serialize(writer); err != nil {
+		t.Fatal(err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.String()
+}
+
+func decryptWithEntity(t *testing.T, entity *openpgp.Entity, encrypted string) string {
+	data, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := openpgp.ReadMessage(bytes.NewReader(data), openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := io.ReadAll(message.UnverifiedBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(decrypted)
+}
+
+func TestEncryptWithPgpKey(t *testing.T) {
+	entity, err := openpgp.NewEntity("tester", "", "tester@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fingerprint, encrypted, err := EncryptWithPgpKey(armoredPublicKey(t, entity), "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fingerprint != hex.EncodeToString(entity.PrimaryKey.Fingerprint[:]) {
+		t.Errorf("unexpected fingerprint : %s", fingerprint)
+	}
+	if decryptWithEntity(t, entity, encrypted) != "secret" {
+		t.Error("decrypted value does not match")
+	}
+
+	if _, _, err = EncryptWithPgpKey("not a key", "secret"); err == nil {
+		t.Error("invalid key should not be allowed")
+	}
+}
+
+func TestPreparePgpEntity(t *testing.T) {
+	entity, err := openpgp.NewEntity("tester", "", "tester@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prepared, err := PreparePgpEntity(armoredPublicKey(t, entity))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, encrypted, err := EncryptWithPgpEntity(prepared, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decryptWithEntity(t, entity, encrypted) != "secret" {
+		t.Error("decrypted value does not match")
+	}
+
+	t.Setenv(PgpKeyringEnvName, filepath.Join(t.TempDir(), "missing.gpg"))
+	if _, err = PreparePgpEntity("keybase:unknown"); err == nil {
+		t.Error("unresolved keyring reference should not be allowed")
+	}
+}
+
+func TestRetrievePgpEntityFromKeyring(t *testing.T) {
+	entity, err := openpgp.NewEntity("tester", "", "tester@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buffer := new(bytes.Buffer)
+	if err = entity.Serialize(buffer); err != nil {
+		t.Fatal(err)
+	}
+	keyringPath := filepath.Join(t.TempDir(), "pubring.gpg")
+	if err = os.WriteFile(keyringPath, buffer.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := RetrievePgpEntity("keybase:tester", keyringPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.PrimaryKey.KeyId != entity.PrimaryKey.KeyId {
+		t.Error("unexpected key found")
+	}
+
+	if _, err = RetrievePgpEntity("keybase:unknown", keyringPath); err == nil {
+		t.Error("unknown name should not be found")
+	}
+}
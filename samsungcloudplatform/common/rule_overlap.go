@@ -0,0 +1,254 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	OverlapCheckError string = "ERROR"
+	OverlapCheckWarn  string = "WARN"
+	OverlapCheckNone  string = "NONE"
+)
+
+// RuleService is a protocol and port value pair of a network rule.
+// Type can be TCP, UDP, ICMP, ALL, TCP_ALL, UDP_ALL or ICMP_ALL.
+type RuleService struct {
+	Type  string
+	Value string
+}
+
+// NetworkRule is a protocol independent view of security group and firewall rules used for overlap checks.
+// Empty address list matches every address.
+type NetworkRule struct {
+	Id                   string
+	Direction            string
+	Action               string
+	SourceAddresses      []string
+	DestinationAddresses []string
+	Services             []RuleService
+}
+
+// NormalizeCidr appends /32 to a single ip address and converts the address to its network address.
+func NormalizeCidr(address string) string {
+	address = strings.TrimSpace(address)
+	if !strings.Contains(address, "/") {
+		address = address + "/32"
+	}
+	_, network, err := net.ParseCIDR(address)
+	if err != nil {
+		return address
+	}
+	return network.String()
+}
+
+func NormalizeCidrList(addresses []string) []string {
+	var result []string
+	for _, address := range addresses {
+		result = append(result, NormalizeCidr(address))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func cidrContains(outer string, inner string) bool {
+	_, outerNetwork, err := net.ParseCIDR(NormalizeCidr(outer))
+	if err != nil {
+		return outer == inner
+	}
+	_, innerNetwork, err := net.ParseCIDR(NormalizeCidr(inner))
+	if err != nil {
+		return false
+	}
+	outerOnes, _ := outerNetwork.Mask.Size()
+	innerOnes, _ := innerNetwork.Mask.Size()
+	return outerOnes <= innerOnes && outerNetwork.Contains(innerNetwork.IP)
+}
+
+func addressesCover(outer []string, inner []string) bool {
+	if len(outer) == 0 {
+		return true
+	}
+	if len(inner) == 0 {
+		return false
+	}
+	for _, i := range inner {
+		covered := false
+		for _, o := range outer {
+			if cidrContains(o, i) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePortRange parses "80" or "8000-8080". Empty value means every port.
+func parsePortRange(value string) (int, int, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 || strings.ToUpper(value) == "ALL" {
+		return 0, 65535, true
+	}
+	ports := strings.SplitN(value, "-", 2)
+	from, err := strconv.Atoi(strings.TrimSpace(ports[0]))
+	if err != nil {
+		return 0, 0, false
+	}
+	to := from
+	if len(ports) == 2 {
+		to, err = strconv.Atoi(strings.TrimSpace(ports[1]))
+		if err != nil {
+			return 0, 0, false
+		}
+	}
+	return from, to, true
+}
+
+func normalizeService(service RuleService) RuleService {
+	serviceType := strings.ToUpper(strings.TrimSpace(service.Type))
+	value := strings.TrimSpace(service.Value)
+	switch serviceType {
+	case "TCP_ALL", "UDP_ALL", "ICMP_ALL":
+		return RuleService{Type: strings.TrimSuffix(serviceType, "_ALL"), Value: ""}
+	case "ICMP":
+		return RuleService{Type: serviceType, Value: value}
+	}
+	if from, to, ok := parsePortRange(value); ok {
+		if from == 0 && to == 65535 {
+			value = ""
+		} else if from == to {
+			value = strconv.Itoa(from)
+		} else {
+			value = fmt.Sprintf("%d-%d", from, to)
+		}
+	}
+	return RuleService{Type: serviceType, Value: value}
+}
+
+func serviceCovers(outer RuleService, inner RuleService) bool {
+	outer = normalizeService(outer)
+	inner = normalizeService(inner)
+	if outer.Type == "ALL" {
+		return true
+	}
+	if outer.Type != inner.Type {
+		return false
+	}
+	if len(outer.Value) == 0 {
+		return true
+	}
+	if outer.Type == "ICMP" {
+		return outer.Value == inner.Value
+	}
+	outerFrom, outerTo, ok := parsePortRange(outer.Value)
+	if !ok {
+		return outer.Value == inner.Value
+	}
+	innerFrom, innerTo, ok := parsePortRange(inner.Value)
+	if !ok {
+		return false
+	}
+	return outerFrom <= innerFrom && innerTo <= outerTo
+}
+
+func servicesCover(outer []RuleService, inner []RuleService) bool {
+	for _, i := range inner {
+		covered := false
+		for _, o := range outer {
+			if serviceCovers(o, i) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func directionCovers(outer string, inner string) bool {
+	outer = strings.ToUpper(outer)
+	inner = strings.ToUpper(inner)
+	return outer == inner || outer == "IN_OUT"
+}
+
+// RuleCovers checks whether every traffic matched by inner is also matched by outer
+func RuleCovers(outer NetworkRule, inner NetworkRule) bool {
+	return directionCovers(outer.Direction, inner.Direction) &&
+		addressesCover(outer.SourceAddresses, inner.SourceAddresses) &&
+		addressesCover(outer.DestinationAddresses, inner.DestinationAddresses) &&
+		servicesCover(outer.Services, inner.Services)
+}
+
+// RuleEquals checks whether two rules match exactly the same traffic with the same action
+func RuleEquals(a NetworkRule, b NetworkRule) bool {
+	return strings.ToUpper(a.Action) == strings.ToUpper(b.Action) && RuleCovers(a, b) && RuleCovers(b, a)
+}
+
+func ruleName(rule NetworkRule, index int) string {
+	if len(rule.Id) != 0 {
+		return rule.Id
+	}
+	return fmt.Sprintf("rule #%d", index+1)
+}
+
+// CheckRuleOverlaps compares new rules with the existing rules and each other.
+// Existing rules are evaluated before the new rules, and new rules are regarded as unordered.
+// Returns the list of duplicated rules and the list of shadowed (redundant) rules.
+func CheckRuleOverlaps(newRules []NetworkRule, existingRules []NetworkRule) ([]string, []string) {
+	var duplicates []string
+	var shadowed []string
+
+	for i, newRule := range newRules {
+		for _, existingRule := range existingRules {
+			if RuleEquals(existingRule, newRule) {
+				duplicates = append(duplicates, fmt.Sprintf("%s duplicates existing rule %s", ruleName(newRule, i), existingRule.Id))
+			} else if RuleCovers(existingRule, newRule) {
+				shadowed = append(shadowed, fmt.Sprintf("%s is shadowed by existing rule %s", ruleName(newRule, i), existingRule.Id))
+			}
+		}
+		for j := 0; j < i; j++ {
+			if RuleEquals(newRules[j], newRule) {
+				duplicates = append(duplicates, fmt.Sprintf("%s duplicates %s", ruleName(newRule, i), ruleName(newRules[j], j)))
+			} else if RuleCovers(newRules[j], newRule) {
+				shadowed = append(shadowed, fmt.Sprintf("%s is shadowed by %s", ruleName(newRule, i), ruleName(newRules[j], j)))
+			} else if RuleCovers(newRule, newRules[j]) {
+				// New rules are not ordered, so check the opposite direction as well
+				shadowed = append(shadowed, fmt.Sprintf("%s is shadowed by %s", ruleName(newRules[j], j), ruleName(newRule, i)))
+			}
+		}
+	}
+
+	return duplicates, shadowed
+}
+
+// RuleOverlapError builds the CustomizeDiff error from the overlap check result.
+// Duplicated rules are always reported as an error. Shadowed rules are reported as an error in ERROR mode,
+// otherwise they are only logged as a warning.
+func RuleOverlapError(mode string, duplicates []string, shadowed []string) error {
+	if mode == OverlapCheckNone {
+		return nil
+	}
+	var messages []string
+	messages = append(messages, duplicates...)
+	if mode == OverlapCheckError {
+		messages = append(messages, shadowed...)
+	} else {
+		for _, message := range shadowed {
+			log.Printf("[WARN] %s", message)
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("rule overlap check failed: \n%s", strings.Join(messages, "\n"))
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestNormalizeCidr(t *testing.T) {
+	if NormalizeCidr("10.0.0.1") != "10.0.0.1/32" {
+		t.Error("single ip should be normalized to /32")
+	}
+	if NormalizeCidr("10.0.0.5/24") != "10.0.0.0/24" {
+		t.Error("cidr should be normalized to network address")
+	}
+}
+
+func TestCheckRuleOverlaps(t *testing.T) {
+	existing := []NetworkRule{
+		{
+			Id:              "RULE-1",
+			Direction:       "IN",
+			Action:          "ALLOW",
+			SourceAddresses: []string{"10.0.0.0/16"},
+			Services:        []RuleService{{Type: "TCP", Value: "8000-9000"}},
+		},
+	}
+
+	duplicate := NetworkRule{
+		Direction:       "IN",
+		Action:          "ALLOW",
+		SourceAddresses: []string{"10.0.1.1/16"},
+		Services:        []RuleService{{Type: "TCP", Value: "8000-9000"}},
+	}
+	duplicates, shadowed := CheckRuleOverlaps([]NetworkRule{duplicate}, existing)
+	if len(duplicates) != 1 || len(shadowed) != 0 {
+		t.Errorf("same rule should be reported as duplicate : %v, %v", duplicates, shadowed)
+	}
+
+	narrower := NetworkRule{
+		Direction:       "IN",
+		Action:          "ALLOW",
+		SourceAddresses: []string{"10.0.3.4"},
+		Services:        []RuleService{{Type: "TCP", Value: "8080"}},
+	}
+	duplicates, shadowed = CheckRuleOverlaps([]NetworkRule{narrower}, existing)
+	if len(duplicates) != 0 || len(shadowed) != 1 {
+		t.Errorf("narrower rule should be reported as shadowed : %v, %v", duplicates, shadowed)
+	}
+
+	otherDirection := narrower
+	otherDirection.Direction = "OUT"
+	duplicates, shadowed = CheckRuleOverlaps([]NetworkRule{otherDirection}, existing)
+	if len(duplicates) != 0 || len(shadowed) != 0 {
+		t.Errorf("rule of other direction should not overlap : %v, %v", duplicates, shadowed)
+	}
+
+	wider := NetworkRule{
+		Direction:       "IN",
+		Action:          "ALLOW",
+		SourceAddresses: []string{"10.0.0.0/8"},
+		Services:        []RuleService{{Type: "TCP_ALL"}},
+	}
+	duplicates, shadowed = CheckRuleOverlaps([]NetworkRule{narrower, wider}, nil)
+	if len(duplicates) != 0 || len(shadowed) != 1 {
+		t.Errorf("new rules should be checked regardless of order : %v, %v", duplicates, shadowed)
+	}
+}
+
+func TestRuleOverlapError(t *testing.T) {
+	if RuleOverlapError(OverlapCheckWarn, nil, []string{"shadowed"}) != nil {
+		t.Error("shadowed rule should not be an error in WARN mode")
+	}
+	if RuleOverlapError(OverlapCheckError, nil, []string{"shadowed"}) == nil {
+		t.Error("shadowed rule should be an error in ERROR mode")
+	}
+	if RuleOverlapError(OverlapCheckNone, []string{"duplicated"}, nil) != nil {
+		t.Error("nothing should be reported in NONE mode")
+	}
+}
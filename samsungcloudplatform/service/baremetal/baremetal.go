@@ -10,6 +10,7 @@ import (
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/service/image"
 	tfTags "github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/service/tag"
+	baremetal2 "github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/bare-metal-server"
 	publicip2 "github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/public-ip2"
 	"github.com/antihax/optional"
 	"github.com/hashicorp/go-cty/cty"
@@ -203,6 +204,12 @@ func ResourceBareMetalServer() *schema.Resource {
 				ValidateDiagFunc: common.ValidatePassword8to20,
 				Description:      "Admin account password for this bare-metal server OS. (CAUTION) The actual plain-text password will be sent to your email.",
 			},
+			"node_import_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Import ids of samsungcloudplatform_bm_server_node by the server name, which are used to migrate the servers to samsungcloudplatform_bm_server_node",
+			},
 			"tags": tfTags.TagsSchema(),
 		},
 		Description: "Provides a Bare-metal Server resource.",
@@ -391,14 +398,8 @@ func getChangeDiffIndex(rd *schema.ResourceData, key string) ([]int, []string, e
 	return idxList, newValue, nil
 }
 
-func resourceBareMetalServerCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
-	var err error = nil
-	defer func() {
-		if err != nil {
-			diagnostics = diag.FromErr(err)
-		}
-	}()
-
+// getBareMetalServerCreateRequest makes the create request of the servers with the common attributes of rd
+func getBareMetalServerCreateRequest(ctx context.Context, rd *schema.ResourceData, meta interface{}, servers common.HclListObject) (baremetal.BMServerCreateRequest, error) {
 	inst := meta.(*client.Instance)
 
 	isDeleteProtected := rd.Get("delete_protection").(bool)
@@ -417,18 +418,16 @@ func resourceBareMetalServerCreate(ctx context.Context, rd *schema.ResourceData,
 	vpcId := rd.Get("vpc_id").(string)
 	imageId := rd.Get("image_id").(string)
 
-	servers := rd.Get("servers").(common.HclListObject)
-
 	// Get vpc info
 	vpcInfo, _, err := inst.Client.Vpc.GetVpcInfo(ctx, vpcId)
 	if err != nil {
-		return
+		return baremetal.BMServerCreateRequest{}, err
 	}
 
 	blockId := ""
 	projectDetails, err := inst.Client.Project.GetProjectInfo(ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return baremetal.BMServerCreateRequest{}, err
 	}
 	for _, zone := range projectDetails.ServiceZones {
 		if zone.ServiceZoneId == vpcInfo.ServiceZoneId {
@@ -436,12 +435,12 @@ func resourceBareMetalServerCreate(ctx context.Context, rd *schema.ResourceData,
 		}
 	}
 	if blockId == "" {
-		return diag.FromErr(errors.New("vpc info is not valid"))
+		return baremetal.BMServerCreateRequest{}, errors.New("vpc info is not valid")
 	}
 
 	isOsWindows, targetProductGroupId, err := getImageInfo(ctx, vpcInfo.ServiceZoneId, imageId, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return baremetal.BMServerCreateRequest{}, err
 	}
 
 	if !isOsWindows && adminAccount != common.LinuxAdminAccount {
@@ -450,19 +449,17 @@ func resourceBareMetalServerCreate(ctx context.Context, rd *schema.ResourceData,
 	}
 
 	if isOsWindows && (adminAccount == common.WindowsAdminAccount || len(adminAccount) < 5) {
-		diagnostics = diag.Errorf("Windows admin account must be 5 to 20 alpha-numeric characters with special character and not be 'administrator'.")
-		return
+		return baremetal.BMServerCreateRequest{}, fmt.Errorf("Windows admin account must be 5 to 20 alpha-numeric characters with special character and not be 'administrator'.")
 	}
 
 	if len(targetProductGroupId) == 0 {
-		diagnostics = diag.Errorf("Product group id not found from image")
-		return
+		return baremetal.BMServerCreateRequest{}, fmt.Errorf("Product group id not found from image")
 	}
 
 	if isOsWindows {
 		err := common.ValidateServerNameInWindowImage(servers)
 		if err != nil {
-			return diag.FromErr(err)
+			return baremetal.BMServerCreateRequest{}, err
 		}
 	}
 
@@ -470,45 +467,42 @@ func resourceBareMetalServerCreate(ctx context.Context, rd *schema.ResourceData,
 	productGroup, err := inst.Client.Product.GetProductGroup(ctx, targetProductGroupId)
 	//productGroup, err := inst.Client.Product.GetProducesList(ctx, vpcInfo.ServiceZoneId, targetProductGroupId, "")
 	if err != nil {
-		return diag.FromErr(err)
+		return baremetal.BMServerCreateRequest{}, err
 	}
 
 	diskProductNameToId := common.ProductToIdMap(common.ProductDisk, &productGroup)
 	if len(diskProductNameToId) == 0 {
-		diagnostics = diag.Errorf("Failed to find external disk product")
-		return
+		return baremetal.BMServerCreateRequest{}, fmt.Errorf("Failed to find external disk product")
 	}
 
 	contractToId := common.ProductToIdMap(common.ProductContractDiscount, &productGroup)
 	if len(contractToId) == 0 {
-		diagnostics = diag.Errorf("Failed to find contract info")
-		return
+		return baremetal.BMServerCreateRequest{}, fmt.Errorf("Failed to find contract info")
 	}
 
 	// Find bare-metal scaling
 	scaleId, err := client.FindScaleProduct(ctx, inst.Client, targetProductGroupId, cpuCount, memorySizeGB)
 	if err != nil {
-		return
+		return baremetal.BMServerCreateRequest{}, err
 	}
 
 	// Find contract
 	contractId, ok := contractToId[contractDiscount]
 	if !ok {
-		diagnostics = diag.Errorf("Invalid contract")
-		return
+		return baremetal.BMServerCreateRequest{}, fmt.Errorf("Invalid contract")
 	}
 
 	blockStorageInfoList, err := ConvertBlockStorageList(blockStorageList, diskProductNameToId)
 	if err != nil {
-		return diag.FromErr(err)
+		return baremetal.BMServerCreateRequest{}, err
 	}
 
 	serverDetailList, err := ConvertBaremetalServerList(servers, blockStorageInfoList, scaleId)
 	if err != nil {
-		return diag.FromErr(err)
+		return baremetal.BMServerCreateRequest{}, err
 	}
 
-	createRequest := baremetal.BMServerCreateRequest{
+	return baremetal.BMServerCreateRequest{
 		BlockId:                   blockId,
 		ContractId:                contractId,
 		DeletionProtectionEnabled: isDeleteProtected,
@@ -521,6 +515,24 @@ func resourceBareMetalServerCreate(ctx context.Context, rd *schema.ResourceData,
 		ServiceZoneId:             vpcInfo.ServiceZoneId,
 		ServerDetails:             serverDetailList,
 		VpcId:                     vpcId,
+	}, nil
+}
+
+func resourceBareMetalServerCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
+	var err error = nil
+	defer func() {
+		if err != nil {
+			diagnostics = diag.FromErr(err)
+		}
+	}()
+
+	inst := meta.(*client.Instance)
+
+	servers := rd.Get("servers").(common.HclListObject)
+
+	createRequest, err := getBareMetalServerCreateRequest(ctx, rd, meta, servers)
+	if err != nil {
+		return
 	}
 
 	createResponse, err := inst.Client.BareMetal.CreateBareMetalServer(ctx, createRequest, rd.Get("tags").(map[string]interface{}))
@@ -586,10 +598,53 @@ func resourceBareMetalServerRead(ctx context.Context, rd *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	err = setBareMetalServerCommonAttributes(ctx, inst, rd, bmServerInfo)
+	if err != nil {
+		return
+	}
+
+	servers := common.HclListObject{}
+	serverNames := make([]string, 0, len(baremetalIds))
+
+	for _, baremetalId := range baremetalIds {
+		bmServerInfo, _, err := inst.Client.BareMetal.GetBareMetalServerDetail(ctx, baremetalId)
+		if err != nil {
+			rd.SetId("")
+			if common.IsDeleted(err) {
+				return nil
+			}
+			return diag.FromErr(err)
+		}
+
+		serverInfo, err := getBareMetalServerInfo(ctx, inst, bmServerInfo)
+		if err != nil {
+			diagnostics = diag.FromErr(err)
+			return
+		}
+
+		servers = append(servers, serverInfo)
+		serverNames = append(serverNames, bmServerInfo.BareMetalServerName)
+	}
+
+	rd.Set("servers", servers)
+
+	var blockStorageNames []string
+	for _, blockStorage := range rd.Get("block_storages").([]interface{}) {
+		blockStorageNames = append(blockStorageNames, blockStorage.(map[string]interface{})["name"].(string))
+	}
+	rd.Set("node_import_ids", getBareMetalServerNodeImportIds(baremetalIds, serverNames, blockStorageNames))
+
+	tfTags.SetTags(ctx, rd, meta, baremetalIds[0])
+
+	return nil
+}
+
+// setBareMetalServerCommonAttributes sets the attributes which all the servers of the request have in common
+func setBareMetalServerCommonAttributes(ctx context.Context, inst *client.Instance, rd *schema.ResourceData, bmServerInfo baremetal2.BareMetalServerDetailResponse) error {
 	// Get product group information
 	productGroup, err := inst.Client.Product.GetProductGroup(ctx, bmServerInfo.ProductGroupId)
 	if err != nil {
-		return diag.FromErr(err)
+		return err
 	}
 
 	productIdToNameMapper := make(map[string]string)
@@ -628,7 +683,7 @@ func resourceBareMetalServerRead(ctx context.Context, rd *schema.ResourceData, m
 	// Set cpu / memory
 	scale, err := client.FindProductById(ctx, inst.Client, bmServerInfo.ProductGroupId, bmServerInfo.ServerTypeId)
 	if err != nil {
-		return
+		return err
 	}
 
 	cpuFound := false
@@ -653,69 +708,54 @@ func resourceBareMetalServerRead(ctx context.Context, rd *schema.ResourceData, m
 		}
 	}
 	if !cpuFound || !memoryFound {
-		return
+		return err
 	}
 
-	servers := common.HclListObject{}
+	return nil
+}
 
-	for _, baremetalId := range baremetalIds {
-		bmServerInfo, _, err := inst.Client.BareMetal.GetBareMetalServerDetail(ctx, baremetalId)
+// getBareMetalServerInfo returns the attributes of each server
+func getBareMetalServerInfo(ctx context.Context, inst *client.Instance, bmServerInfo baremetal2.BareMetalServerDetailResponse) (common.HclKeyValueObject, error) {
+	serverInfo := common.HclKeyValueObject{}
+
+	serverInfo["bm_server_name"] = bmServerInfo.BareMetalServerName
+	serverInfo["ipv4"] = bmServerInfo.IpAddress
+	serverInfo["nat_enabled"] = bmServerInfo.NatIpAddress != ""
+	serverInfo["local_subnet_enabled"] = bmServerInfo.BareMetalLocalSubnetId != ""
+	serverInfo["local_subnet_id"] = bmServerInfo.BareMetalLocalSubnetId
+	serverInfo["local_subnet_ipv4"] = bmServerInfo.BareMetalLocalSubnetIpAddress
+	serverInfo["use_hyper_threading"] = bmServerInfo.UseHyperThreading
+	serverInfo["use_dns"] = bmServerInfo.DnsEnabled == "Y"
+	serverInfo["state"] = strings.ToUpper(bmServerInfo.BareMetalServerState)
+
+	natIpv4 := bmServerInfo.NatIpAddress
+
+	if natIpv4 != "" {
+		publicIpInfo, err := inst.Client.PublicIp.GetPublicIps(ctx, &publicip2.PublicIpOpenApiV3ControllerApiListPublicIpsV3Opts{
+			IpAddress:     optional.NewString(natIpv4),
+			VpcId:         optional.NewString(bmServerInfo.VpcId),
+			PublicIpState: optional.String{},
+			UplinkType:    optional.String{},
+			CreatedBy:     optional.String{},
+			Page:          optional.Int32{},
+			Size:          optional.Int32{},
+			Sort:          optional.Interface{},
+		})
 		if err != nil {
-			rd.SetId("")
-			if common.IsDeleted(err) {
-				return nil
-			}
-			return diag.FromErr(err)
+			return nil, err
 		}
 
-		serverInfo := common.HclKeyValueObject{}
-
-		serverInfo["bm_server_name"] = bmServerInfo.BareMetalServerName
-		serverInfo["ipv4"] = bmServerInfo.IpAddress
-		serverInfo["nat_enabled"] = bmServerInfo.NatIpAddress != ""
-		serverInfo["local_subnet_enabled"] = bmServerInfo.BareMetalLocalSubnetId != ""
-		serverInfo["local_subnet_id"] = bmServerInfo.BareMetalLocalSubnetId
-		serverInfo["local_subnet_ipv4"] = bmServerInfo.BareMetalLocalSubnetIpAddress
-		serverInfo["use_hyper_threading"] = bmServerInfo.UseHyperThreading
-		serverInfo["use_dns"] = bmServerInfo.DnsEnabled == "Y"
-		serverInfo["state"] = strings.ToUpper(bmServerInfo.BareMetalServerState)
-
-		natIpv4 := bmServerInfo.NatIpAddress
-
-		if natIpv4 != "" {
-			publicIpInfo, err := inst.Client.PublicIp.GetPublicIps(ctx, &publicip2.PublicIpOpenApiV3ControllerApiListPublicIpsV3Opts{
-				IpAddress:     optional.NewString(natIpv4),
-				VpcId:         optional.NewString(bmServerInfo.VpcId),
-				PublicIpState: optional.String{},
-				UplinkType:    optional.String{},
-				CreatedBy:     optional.String{},
-				Page:          optional.Int32{},
-				Size:          optional.Int32{},
-				Sort:          optional.Interface{},
-			})
-			if err != nil {
-				diagnostics = diag.FromErr(err)
-				return
-			}
-
-			if len(publicIpInfo.Contents) == 0 {
-				// this case is found on auto assign mode
-				serverInfo["public_ip_id"] = ""
-			} else {
-				serverInfo["public_ip_id"] = publicIpInfo.Contents[0].PublicIpAddressId
-			}
-		} else {
+		if len(publicIpInfo.Contents) == 0 {
+			// this case is found on auto assign mode
 			serverInfo["public_ip_id"] = ""
+		} else {
+			serverInfo["public_ip_id"] = publicIpInfo.Contents[0].PublicIpAddressId
 		}
-
-		servers = append(servers, serverInfo)
+	} else {
+		serverInfo["public_ip_id"] = ""
 	}
 
-	rd.Set("servers", servers)
-
-	tfTags.SetTags(ctx, rd, meta, baremetalIds[0])
-
-	return nil
+	return serverInfo, nil
 }
 
 func resourceBareMetalServerUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
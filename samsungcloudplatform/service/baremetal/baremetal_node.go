@@ -0,0 +1,548 @@
+package baremetal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/baremetal"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	tfTags "github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/service/tag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_bm_server_node", ResourceBareMetalServerNode())
+}
+
+// bareMetalServerNodeKeys are the attributes of a server in the servers block of samsungcloudplatform_bm_server
+var bareMetalServerNodeKeys = []string{
+	"bm_server_name",
+	"ipv4",
+	"nat_enabled",
+	"public_ip_id",
+	"local_subnet_enabled",
+	"local_subnet_id",
+	"local_subnet_ipv4",
+	"use_dns",
+	"use_hyper_threading",
+	"state",
+}
+
+func ResourceBareMetalServerNode() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBareMetalServerNodeCreate,
+		ReadContext:   resourceBareMetalServerNodeRead,
+		UpdateContext: resourceBareMetalServerNodeUpdate,
+		DeleteContext: resourceBareMetalServerNodeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBareMetalServerNodeImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"bm_server_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: common.ValidateName3to28AlphaNumberDash,
+				Description:      "Bare-metal server name",
+			},
+			"ipv4": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: common.ValidateIpv4WithEmptyValue,
+				Description:      "IP address of this bare-metal server",
+			},
+			"nat_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable NAT feature for this bare-metal server.",
+			},
+			"public_ip_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Public IP id of this bare-metal server. Public-IP must be a valid public-ip resource which is attached to the VPC.",
+			},
+			"local_subnet_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable local subnet for this bare-metal server",
+			},
+			"local_subnet_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Local Subnet id of this bare-metal server. Subnet must be a valid subnet resource which is attached to the VPC.",
+			},
+			"local_subnet_ipv4": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: common.ValidateIpv4WithEmptyValue,
+				Description:      "Local IP address of this bare-metal server",
+			},
+			"use_dns": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Enable DNS feature for this bare-metal server.",
+			},
+			"use_hyper_threading": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "N",
+				Description: "Enable hyper-threading feature for this bare-metal server.(ex. Y, N)",
+			},
+			"state": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          common.RunningState,
+				ValidateDiagFunc: validateBmState,
+				Description:      "Baremetal Server State(ex. RUNNING, STOPPED). It must be RUNNING on creation.",
+			},
+			"delete_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Enable delete protection for this bare-metal server",
+			},
+			"cpu_count": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "CPU core count(8, 16, ..)",
+				ValidateDiagFunc: common.ValidatePositiveInt,
+			},
+			"memory_size_gb": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Memory size in gigabytes(16, 32,..)",
+				ValidateDiagFunc: common.ValidatePositiveInt,
+			},
+			"contract_discount": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Contract : None, 1-year, 3-year",
+			},
+			"block_storages": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Block storages which are created with this bare-metal server. Block storages attached by samsungcloudplatform_bm_block_storage are not included.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Block storage name",
+						},
+						"product_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     "SSD",
+							Description: "Storage product name : SSD",
+						},
+						"storage_size_gb": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Storage size in gigabytes",
+						},
+						"encrypted": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     false,
+							Description: "Use encryption for this storage",
+						},
+					},
+				},
+			},
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "VPC id of this bare-metal server",
+			},
+			"image_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Image id of this bare-metal server",
+			},
+			"initial_script": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+				Description: "Initialization script",
+			},
+			"subnet_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Subnet id of this bare-metal server. Subnet must be a valid subnet resource which is attached to the VPC.",
+			},
+			"admin_account": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: common.ValidateName3to20LowerAlphaAndNumberOnly,
+				Description:      "Admin account for this bare-metal server OS. For linux, this must be 'root'. For Windows, this must not be 'administrator'. It is only used on creation.",
+			},
+			"admin_password": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				ValidateDiagFunc: common.ValidatePassword8to20,
+				Description:      "Admin account password for this bare-metal server OS. It is only used on creation. (CAUTION) The actual plain-text password will be sent to your email.",
+			},
+			"tags": tfTags.TagsSchema(),
+		},
+		Description: "Provides a single Bare-metal Server resource. Unlike samsungcloudplatform_bm_server, each server is managed by its own resource.",
+	}
+}
+
+func resourceBareMetalServerNodeCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
+	var err error = nil
+	defer func() {
+		if err != nil {
+			diagnostics = diag.FromErr(err)
+		}
+	}()
+
+	inst := meta.(*client.Instance)
+
+	server := common.HclKeyValueObject{}
+	for _, key := range bareMetalServerNodeKeys {
+		server[key] = rd.Get(key)
+	}
+
+	createRequest, err := getBareMetalServerCreateRequest(ctx, rd, meta, common.HclListObject{server})
+	if err != nil {
+		return
+	}
+
+	createResponse, err := inst.Client.BareMetal.CreateBareMetalServer(ctx, createRequest, rd.Get("tags").(map[string]interface{}))
+	if err != nil {
+		return
+	}
+
+	err = WaitForBMServerStatus(ctx, inst.Client, createResponse.ResourceId, common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+	if err != nil {
+		return
+	}
+
+	rd.SetId(createResponse.ResourceId)
+
+	return resourceBareMetalServerNodeRead(ctx, rd, meta)
+}
+
+func resourceBareMetalServerNodeRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	bmServerInfo, _, err := inst.Client.BareMetal.GetBareMetalServerDetail(ctx, rd.Id())
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	declaredBlockStorages := rd.Get("block_storages").(common.HclListObject)
+
+	err = setBareMetalServerCommonAttributes(ctx, inst, rd, bmServerInfo)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rd.Set("block_storages", filterDeclaredBlockStorages(declaredBlockStorages, rd.Get("block_storages").(common.HclListObject)))
+
+	serverInfo, err := getBareMetalServerInfo(ctx, inst, bmServerInfo)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for key, value := range serverInfo {
+		rd.Set(key, value)
+	}
+
+	tfTags.SetTags(ctx, rd, meta, rd.Id())
+
+	return nil
+}
+
+func resourceBareMetalServerNodeUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	activeStates := []string{common.RunningState, common.StoppedState}
+	targetState := rd.Get("state").(string)
+
+	// start first, as the other changes are made on the running server
+	if rd.HasChanges("state") && targetState == common.RunningState {
+		err := changeBareMetalServerPowerState(ctx, inst, rd.Id(), targetState)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if rd.HasChanges("delete_protection") {
+		isDeleteProtectionEnabled := "N"
+		if rd.Get("delete_protection").(bool) {
+			isDeleteProtectionEnabled = "Y"
+		}
+		_, err := inst.Client.BareMetal.ChangeBMDeletePolicy(ctx, rd.Id(), isDeleteProtectionEnabled)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if rd.HasChanges("contract_discount") {
+		serverInfo, _, err := inst.Client.BareMetal.GetBareMetalServerDetail(ctx, rd.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		productGroup, err := inst.Client.Product.GetProductGroup(ctx, serverInfo.ProductGroupId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		contractToId := common.ProductToIdMap(common.ProductContractDiscount, &productGroup)
+		if len(contractToId) == 0 {
+			return diag.Errorf("Failed to find contract info")
+		}
+
+		contractId, ok := contractToId[rd.Get("contract_discount").(string)]
+		if !ok {
+			return diag.Errorf("Invalid contract")
+		}
+
+		_, err = inst.Client.BareMetal.ChangeBMContract(ctx, rd.Id(), contractId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if rd.HasChanges("local_subnet_enabled", "local_subnet_id", "local_subnet_ipv4") {
+		oldEnabled, newEnabled := rd.GetChange("local_subnet_enabled")
+
+		if oldEnabled.(bool) {
+			_, err := inst.Client.BareMetal.DetachBMLocalSubnet(ctx, rd.Id())
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			// wait for server state change to editing //
+			time.Sleep(3 * time.Second)
+
+			err = WaitForBMServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		if newEnabled.(bool) {
+			// an empty ip address is assigned automatically
+			localSubnetIp := ""
+			if rd.HasChanges("local_subnet_ipv4") || !oldEnabled.(bool) {
+				localSubnetIp = rd.Get("local_subnet_ipv4").(string)
+			}
+			_, err := inst.Client.BareMetal.AttachBMLocalSubnet(ctx, rd.Id(), rd.Get("local_subnet_id").(string), localSubnetIp)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			// wait for server state change to editing //
+			time.Sleep(3 * time.Second)
+
+			err = WaitForBMServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if rd.HasChanges("nat_enabled", "public_ip_id") {
+		oldEnabled, newEnabled := rd.GetChange("nat_enabled")
+
+		if oldEnabled.(bool) {
+			_, err := inst.Client.BareMetal.DisableBMNat(ctx, rd.Id())
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			err = WaitForBMServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		if newEnabled.(bool) {
+			publicIpId := rd.Get("public_ip_id").(string)
+			addressType := "AUTO"
+			if publicIpId != "" {
+				addressType = "MANUAL"
+			}
+			_, err := inst.Client.BareMetal.EnableBMNat(ctx, rd.Id(), addressType, publicIpId)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			err = WaitForBMServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if rd.HasChanges("state") && targetState == common.StoppedState {
+		err := changeBareMetalServerPowerState(ctx, inst, rd.Id(), targetState)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err := tfTags.UpdateTags(ctx, rd, meta, rd.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceBareMetalServerNodeRead(ctx, rd, meta)
+}
+
+func resourceBareMetalServerNodeDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	err := WaitForBMServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = inst.Client.BareMetal.DeleteBareMetalServer(ctx, rd.Id())
+	if err != nil && !common.IsDeleted(err) {
+		return diag.FromErr(err)
+	}
+
+	err = WaitForBMServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.DeletedState}, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// filterDeclaredBlockStorages keeps the block storages declared in block_storages,
+// so that the block storages attached by samsungcloudplatform_bm_block_storage do not replace the server
+func filterDeclaredBlockStorages(declared common.HclListObject, blockStorages common.HclListObject) common.HclListObject {
+	declaredNames := make(map[string]bool)
+	for _, item := range declared {
+		declaredNames[item.(common.HclKeyValueObject)["name"].(string)] = true
+	}
+
+	result := common.HclListObject{}
+	for _, item := range blockStorages {
+		if declaredNames[item.(common.HclKeyValueObject)["name"].(string)] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// resourceBareMetalServerNodeImport imports with "<bm_server_id>" or "<bm_server_id>/<block_storage_name>,..." format.
+// Only the listed block storages are read into block_storages, because the block storages attached by
+// samsungcloudplatform_bm_block_storage can not be told apart from the ones created with the server.
+func resourceBareMetalServerNodeImport(ctx context.Context, rd *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	serverId, blockStorageNames, err := parseBareMetalServerNodeImportId(rd.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	blockStorages := common.HclListObject{}
+	for _, name := range blockStorageNames {
+		blockStorages = append(blockStorages, common.HclKeyValueObject{"name": name})
+	}
+
+	rd.SetId(serverId)
+	rd.Set("block_storages", blockStorages)
+
+	return []*schema.ResourceData{rd}, nil
+}
+
+// parseBareMetalServerNodeImportId returns the server id and the block storage names of the import id
+func parseBareMetalServerNodeImportId(importId string) (string, []string, error) {
+	ids := strings.SplitN(importId, "/", 2)
+	if len(strings.TrimSpace(ids[0])) == 0 {
+		return "", nil, fmt.Errorf("invalid import id %q. expected format is <bm_server_id> or <bm_server_id>/<block_storage_name>,...", importId)
+	}
+
+	var blockStorageNames []string
+	if len(ids) == 2 {
+		for _, name := range strings.Split(ids[1], ",") {
+			if name = strings.TrimSpace(name); len(name) != 0 {
+				blockStorageNames = append(blockStorageNames, name)
+			}
+		}
+	}
+	return strings.TrimSpace(ids[0]), blockStorageNames, nil
+}
+
+// getBareMetalServerNodeImportIds returns the import ids of samsungcloudplatform_bm_server_node by the server names of samsungcloudplatform_bm_server.
+// The block storages of samsungcloudplatform_bm_server are created for each server, so every import id has all the block storage names.
+func getBareMetalServerNodeImportIds(serverIds []string, serverNames []string, blockStorageNames []string) map[string]string {
+	importIds := make(map[string]string, len(serverIds))
+	for i, serverId := range serverIds {
+		if i >= len(serverNames) {
+			break
+		}
+		importId := serverId
+		if len(blockStorageNames) != 0 {
+			importId += "/" + strings.Join(blockStorageNames, ",")
+		}
+		importIds[serverNames[i]] = importId
+	}
+	return importIds
+}
+
+// changeBareMetalServerPowerState starts or stops the bare-metal server, and waits until it reaches the state
+func changeBareMetalServerPowerState(ctx context.Context, inst *client.Instance, serverId string, state string) error {
+	err := WaitForBMServerStatus(ctx, inst.Client, serverId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, true)
+	if err != nil {
+		return err
+	}
+
+	serverInfo, _, err := inst.Client.BareMetal.GetBareMetalServerDetail(ctx, serverId)
+	if err != nil {
+		return err
+	}
+
+	state = strings.ToUpper(state)
+	if strings.ToUpper(serverInfo.BareMetalServerState) == state {
+		return nil
+	}
+
+	request := baremetal.BMStartStopRequest{
+		BareMetalServerIds: []string{serverId},
+	}
+	if state == common.StoppedState {
+		_, err = inst.Client.BareMetal.StopBareMetalServer(ctx, request)
+	} else {
+		_, err = inst.Client.BareMetal.StartBareMetalServer(ctx, request)
+	}
+	if err != nil {
+		return err
+	}
+
+	return WaitForBMServerStatus(ctx, inst.Client, serverId, common.VirtualServerProcessingStates(), []string{state}, true)
+}
@@ -0,0 +1,49 @@
+package baremetal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBareMetalServerNodeImportIds(t *testing.T) {
+	serverIds := []string{"BAREMETAL-1", "BAREMETAL-2"}
+	serverNames := []string{"bm-001", "bm-002"}
+
+	importIds := getBareMetalServerNodeImportIds(serverIds, serverNames, []string{"data-01", "data-02"})
+	if len(importIds) != 2 {
+		t.Fatalf("expected 2 import ids, got %v", importIds)
+	}
+	for i, name := range serverNames {
+		serverId, blockStorageNames, err := parseBareMetalServerNodeImportId(importIds[name])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if serverId != serverIds[i] {
+			t.Errorf("%s : expected server id %s, got %s", name, serverIds[i], serverId)
+		}
+		if strings.Join(blockStorageNames, ",") != "data-01,data-02" {
+			t.Errorf("%s : unexpected block storage names %v", name, blockStorageNames)
+		}
+	}
+
+	importIds = getBareMetalServerNodeImportIds(serverIds[:1], serverNames[:1], nil)
+	if importIds["bm-001"] != "BAREMETAL-1" {
+		t.Errorf("unexpected import id %q", importIds["bm-001"])
+	}
+	serverId, blockStorageNames, err := parseBareMetalServerNodeImportId(importIds["bm-001"])
+	if err != nil || serverId != "BAREMETAL-1" || len(blockStorageNames) != 0 {
+		t.Errorf("unexpected import id parse result %s %v %v", serverId, blockStorageNames, err)
+	}
+}
+
+func TestParseBareMetalServerNodeImportId(t *testing.T) {
+	serverId, blockStorageNames, err := parseBareMetalServerNodeImportId("BAREMETAL-1/ data-01 ,,data-02")
+	if err != nil || serverId != "BAREMETAL-1" || strings.Join(blockStorageNames, ",") != "data-01,data-02" {
+		t.Errorf("unexpected import id parse result %s %v %v", serverId, blockStorageNames, err)
+	}
+	for _, importId := range []string{"", "/data-01", " "} {
+		if _, _, err := parseBareMetalServerNodeImportId(importId); err == nil {
+			t.Errorf("import id %q should be invalid", importId)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package certificate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/certificate"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	tfTags "github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/service/tag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_certificate", ResourceCertificate())
+}
+
+func ResourceCertificate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCertificateCreate,
+		ReadContext:   resourceCertificateRead,
+		UpdateContext: resourceCertificateUpdate,
+		DeleteContext: resourceCertificateDelete,
+		// no importer : the certificate and the private key can not be read back, so an imported certificate would be replaced
+		CustomizeDiff: resourceCertificateDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"certificate_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(3, 60)),
+				Description:      "Certificate name. (3 to 60 characters)",
+			},
+			"certificate_body": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "PEM encoded certificate",
+			},
+			"certificate_chain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "PEM encoded intermediate certificates, in order from the issuer of the certificate to the root",
+			},
+			"private_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of the certificate. (PKCS#1, PKCS#8 or SEC 1, not encrypted)",
+			},
+			"min_valid_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          30,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "Certificates expiring within the days are rejected on plan. (default 30)",
+			},
+			"common_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Common name of the certificate",
+			},
+			"not_before": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Start of the validity period (RFC3339)",
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiry date (RFC3339)",
+			},
+			"certificate_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Certificate state",
+			},
+			"tags": tfTags.TagsSchema(),
+		},
+		Description: "Provides a certificate for SSL of load balancer services.",
+	}
+}
+
+// resourceCertificateDiff validates the certificate, the chain and the private key on plan
+func resourceCertificateDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("certificate_body") || !diff.NewValueKnown("certificate_chain") || !diff.NewValueKnown("private_key") {
+		return nil
+	}
+	// the expiry window is checked only when the certificate is uploaded
+	if len(diff.Id()) != 0 && !diff.HasChanges("certificate_body", "certificate_chain", "private_key") {
+		return nil
+	}
+
+	_, err := validateCertificateBundle(
+		diff.Get("certificate_body").(string),
+		diff.Get("certificate_chain").(string),
+		diff.Get("private_key").(string),
+		time.Now(),
+		diff.Get("min_valid_days").(int))
+	return err
+}
+
+func resourceCertificateCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
+	var err error = nil
+	defer func() {
+		if err != nil {
+			diagnostics = diag.FromErr(err)
+		}
+	}()
+
+	inst := meta.(*client.Instance)
+
+	leaf, err := validateCertificateBundle(
+		rd.Get("certificate_body").(string),
+		rd.Get("certificate_chain").(string),
+		rd.Get("private_key").(string),
+		time.Now(),
+		rd.Get("min_valid_days").(int))
+	if err != nil {
+		return
+	}
+
+	result, _, err := inst.Client.Certificate.CreateCertificate(ctx, certificate.CreateRequest{
+		CertificateName:  rd.Get("certificate_name").(string),
+		CertificateBody:  rd.Get("certificate_body").(string),
+		CertificateChain: rd.Get("certificate_chain").(string),
+		PrivateKey:       rd.Get("private_key").(string),
+		Tags:             rd.Get("tags").(map[string]interface{}),
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to upload certificate %s : %w", leaf.Subject.CommonName, err)
+		return
+	}
+
+	rd.SetId(result.CertificateId)
+
+	return resourceCertificateRead(ctx, rd, meta)
+}
+
+func resourceCertificateRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	info, _, err := inst.Client.Certificate.GetCertificate(ctx, rd.Id())
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	rd.Set("certificate_name", info.CertificateName)
+	rd.Set("common_name", info.CommonName)
+	rd.Set("not_before", info.NotBeforeDt.Format(time.RFC3339))
+	rd.Set("not_after", info.NotAfterDt.Format(time.RFC3339))
+	rd.Set("certificate_state", info.CertificateState)
+	tfTags.SetTags(ctx, rd, meta, rd.Id())
+
+	return nil
+}
+
+func resourceCertificateUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := tfTags.UpdateTags(ctx, rd, meta, rd.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceCertificateRead(ctx, rd, meta)
+}
+
+func resourceCertificateDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	_, err := inst.Client.Certificate.DeleteCertificate(ctx, rd.Id())
+	if err != nil && !common.IsDeleted(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
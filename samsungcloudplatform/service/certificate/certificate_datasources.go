@@ -0,0 +1,124 @@
+package certificate
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/certificate"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	certificatemanager "github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/certificate-manager"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	uuid "github.com/satori/go.uuid"
+)
+
+func init() {
+	samsungcloudplatform.RegisterDataSource("samsungcloudplatform_certificates", DatasourceCertificates())
+}
+
+func DatasourceCertificates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCertificateList,
+		Schema: map[string]*schema.Schema{
+			"certificate_name":    {Type: schema.TypeString, Optional: true, Description: "Certificate name"},
+			"common_name":         {Type: schema.TypeString, Optional: true, Description: "Common name of the certificate"},
+			"expires_within_days": {Type: schema.TypeInt, Optional: true, Description: "If set, only certificates expiring within the days are listed. Every page is read, and page is ignored."},
+			"page":                {Type: schema.TypeInt, Optional: true, Default: 0, Description: "Page start number from which to get the list"},
+			"size":                {Type: schema.TypeInt, Optional: true, Default: 20, Description: "Size to get list"},
+			"sort":                {Type: schema.TypeString, Optional: true, Description: "Sort"},
+			"contents":            {Type: schema.TypeList, Computed: true, Description: "Certificate list", Elem: datasourceCertificateElem()},
+			"total_count":         {Type: schema.TypeInt, Computed: true, Description: "Total list size"},
+		},
+		Description: "Provides list of certificates with their expiry dates",
+	}
+}
+
+func dataSourceCertificateList(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	// 0 is a valid value, so the config is checked instead of GetOk
+	filterExpiry := !rd.GetRawConfig().GetAttr("expires_within_days").IsNull()
+	expiresWithinDays := rd.Get("expires_within_days").(int)
+
+	items, err := listCertificates(ctx, inst, rd, filterExpiry)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	now := time.Now()
+	contents := make([]common.HclKeyValueObject, 0)
+	for _, item := range items {
+		daysUntilExpiry := int(math.Floor(item.NotAfterDt.Sub(now).Hours() / 24))
+		if filterExpiry && daysUntilExpiry > expiresWithinDays {
+			continue
+		}
+		contents = append(contents, common.HclKeyValueObject{
+			"certificate_id":    item.CertificateId,
+			"certificate_name":  item.CertificateName,
+			"common_name":       item.CommonName,
+			"certificate_state": item.CertificateState,
+			"not_before":        item.NotBeforeDt.Format(time.RFC3339),
+			"not_after":         item.NotAfterDt.Format(time.RFC3339),
+			"days_until_expiry": daysUntilExpiry,
+			"created_by":        item.CreatedBy,
+			"created_dt":        item.CreatedDt.Format(time.RFC3339),
+		})
+	}
+
+	rd.SetId(uuid.NewV4().String())
+	rd.Set("contents", contents)
+	rd.Set("total_count", len(contents))
+
+	return nil
+}
+
+// listCertificates lists the certificates of the requested page, or every page if allPages is set
+func listCertificates(ctx context.Context, inst *client.Instance, rd *schema.ResourceData, allPages bool) ([]certificatemanager.CertificateResponse, error) {
+	request := certificate.ListCertificatesRequestParam{
+		CertificateName: rd.Get("certificate_name").(string),
+		CommonName:      rd.Get("common_name").(string),
+		Page:            (int32)(rd.Get("page").(int)),
+		Size:            (int32)(rd.Get("size").(int)),
+		Sort:            rd.Get("sort").(string),
+	}
+	if allPages {
+		request.Page = 0
+		if request.Size <= 0 {
+			request.Size = 20
+		}
+	}
+
+	var items []certificatemanager.CertificateResponse
+	for {
+		responses, _, err := inst.Client.Certificate.ListCertificates(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, responses.Contents...)
+
+		if !allPages || len(responses.Contents) == 0 || len(items) >= int(responses.TotalCount) {
+			break
+		}
+		request.Page++
+	}
+	return items, nil
+}
+
+func datasourceCertificateElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"certificate_id":    {Type: schema.TypeString, Computed: true, Description: "Certificate id"},
+			"certificate_name":  {Type: schema.TypeString, Computed: true, Description: "Certificate name"},
+			"common_name":       {Type: schema.TypeString, Computed: true, Description: "Common name of the certificate"},
+			"certificate_state": {Type: schema.TypeString, Computed: true, Description: "Certificate state"},
+			"not_before":        {Type: schema.TypeString, Computed: true, Description: "Start of the validity period (RFC3339)"},
+			"not_after":         {Type: schema.TypeString, Computed: true, Description: "Expiry date (RFC3339)"},
+			"days_until_expiry": {Type: schema.TypeInt, Computed: true, Description: "Days until the expiry date. Negative if expired."},
+			"created_by":        {Type: schema.TypeString, Computed: true, Description: "The person who created the resource"},
+			"created_dt":        {Type: schema.TypeString, Computed: true, Description: "Creation date"},
+		},
+	}
+}
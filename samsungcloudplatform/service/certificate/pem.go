@@ -0,0 +1,157 @@
+package certificate
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// parsePemCertificates parses all CERTIFICATE blocks in order
+func parsePemCertificates(value string) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	rest := []byte(strings.TrimSpace(value))
+	for len(bytes.TrimSpace(rest)) != 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, fmt.Errorf("failed to decode PEM block %d", len(certificates)+1)
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("PEM block %d is %s, but it must be CERTIFICATE", len(certificates)+1, block.Type)
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %d : %w", len(certificates)+1, err)
+		}
+		certificates = append(certificates, certificate)
+	}
+	return certificates, nil
+}
+
+// parsePemPrivateKey parses a PKCS#1, PKCS#8 or SEC 1 private key
+func parsePemPrivateKey(value string) (crypto.PrivateKey, error) {
+	block, rest := pem.Decode([]byte(strings.TrimSpace(value)))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM private key")
+	}
+	if len(bytes.TrimSpace(rest)) != 0 {
+		return nil, fmt.Errorf("private key must be a single PEM block")
+	}
+	if isEncryptedPemBlock(block) {
+		return nil, fmt.Errorf("encrypted private key is not supported")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	return nil, fmt.Errorf("unsupported private key type : %s", block.Type)
+}
+
+// isEncryptedPemBlock checks a legacy RFC 1423 encrypted block, which has a DEK-Info header, and an encrypted PKCS#8 block
+func isEncryptedPemBlock(block *pem.Block) bool {
+	_, ok := block.Headers["DEK-Info"]
+	return ok || block.Type == "ENCRYPTED PRIVATE KEY"
+}
+
+// checkPrivateKeyMatches checks that the private key is the pair of the public key of the certificate
+func checkPrivateKeyMatches(certificate *x509.Certificate, privateKey crypto.PrivateKey) error {
+	var publicKey crypto.PublicKey
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		publicKey = key.Public()
+	case *ecdsa.PrivateKey:
+		publicKey = key.Public()
+	case ed25519.PrivateKey:
+		publicKey = key.Public()
+	default:
+		return fmt.Errorf("unsupported private key type : %T", privateKey)
+	}
+
+	matches := false
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		matches = key.Equal(certificate.PublicKey)
+	case *ecdsa.PublicKey:
+		matches = key.Equal(certificate.PublicKey)
+	case ed25519.PublicKey:
+		matches = key.Equal(certificate.PublicKey)
+	}
+	if !matches {
+		return fmt.Errorf("private key does not match the certificate %s", certificate.Subject.CommonName)
+	}
+	return nil
+}
+
+// checkCertificateChain checks that each certificate is issued by the next one, starting from the leaf certificate
+func checkCertificateChain(certificates []*x509.Certificate) error {
+	for i := 0; i+1 < len(certificates); i++ {
+		child, parent := certificates[i], certificates[i+1]
+		if !bytes.Equal(child.RawIssuer, parent.RawSubject) {
+			return fmt.Errorf("certificate chain is not in order : %s is issued by %s, but the next certificate is %s", child.Subject.CommonName, child.Issuer.CommonName, parent.Subject.CommonName)
+		}
+		if err := child.CheckSignatureFrom(parent); err != nil {
+			return fmt.Errorf("certificate %s is not signed by %s : %w", child.Subject.CommonName, parent.Subject.CommonName, err)
+		}
+	}
+	return nil
+}
+
+// checkCertificateValidity checks that the certificate is valid now, and is not expired within minValidDays
+func checkCertificateValidity(certificate *x509.Certificate, now time.Time, minValidDays int) error {
+	if now.Before(certificate.NotBefore) {
+		return fmt.Errorf("certificate %s is not valid before %s", certificate.Subject.CommonName, certificate.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(certificate.NotAfter) {
+		return fmt.Errorf("certificate %s is expired at %s", certificate.Subject.CommonName, certificate.NotAfter.Format(time.RFC3339))
+	}
+	if now.AddDate(0, 0, minValidDays).After(certificate.NotAfter) {
+		return fmt.Errorf("certificate %s expires at %s, within %d days", certificate.Subject.CommonName, certificate.NotAfter.Format(time.RFC3339), minValidDays)
+	}
+	return nil
+}
+
+// validateCertificateBundle validates the certificate, the chain and the private key, and returns the leaf certificate
+func validateCertificateBundle(body string, chain string, privateKey string, now time.Time, minValidDays int) (*x509.Certificate, error) {
+	certificates, err := parsePemCertificates(body)
+	if err != nil {
+		return nil, fmt.Errorf("certificate_body is invalid : %w", err)
+	}
+	if len(certificates) != 1 {
+		return nil, fmt.Errorf("certificate_body must have only one certificate. put intermediate certificates in certificate_chain")
+	}
+
+	chainCertificates, err := parsePemCertificates(chain)
+	if err != nil {
+		return nil, fmt.Errorf("certificate_chain is invalid : %w", err)
+	}
+	certificates = append(certificates, chainCertificates...)
+	if err := checkCertificateChain(certificates); err != nil {
+		return nil, err
+	}
+	for _, certificate := range certificates {
+		if err := checkCertificateValidity(certificate, now, minValidDays); err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := parsePemPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("private_key is invalid : %w", err)
+	}
+	if err := checkPrivateKeyMatches(certificates[0], key); err != nil {
+		return nil, err
+	}
+	return certificates[0], nil
+}
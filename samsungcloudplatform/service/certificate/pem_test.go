@@ -0,0 +1,108 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	pem         string
+	keyPem      string
+}
+
+func newTestCertificate(t *testing.T, commonName string, notAfter time.Time, issuer *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  issuer == nil || strings.HasSuffix(commonName, "CA"),
+		BasicConstraintsValid: true,
+	}
+
+	parent, signer := template, key
+	if issuer != nil {
+		parent, signer = issuer.certificate, issuer.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		pem:         string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPem:      string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})),
+	}
+}
+
+func TestValidateCertificateBundle(t *testing.T) {
+	now := time.Now()
+	notAfter := now.AddDate(1, 0, 0)
+	root := newTestCertificate(t, "Root CA", notAfter, nil)
+	intermediate := newTestCertificate(t, "Intermediate CA", notAfter, root)
+	leaf := newTestCertificate(t, "www.example.com", notAfter, intermediate)
+	other := newTestCertificate(t, "www.example.com", notAfter, intermediate)
+	expiring := newTestCertificate(t, "www.example.com", now.AddDate(0, 0, 10), intermediate)
+	encryptedKey := string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("key")}))
+	legacyEncryptedKey := string(pem.EncodeToMemory(&pem.Block{
+		Type:    "EC PRIVATE KEY",
+		Headers: map[string]string{"Proc-Type": "4,ENCRYPTED", "DEK-Info": "AES-128-CBC,00000000000000000000000000000000"},
+		Bytes:   []byte("key"),
+	}))
+
+	tests := []struct {
+		name        string
+		body        string
+		chain       string
+		privateKey  string
+		expectedErr string
+	}{
+		{"valid", leaf.pem, intermediate.pem + root.pem, leaf.keyPem, ""},
+		{"no chain", leaf.pem, "", leaf.keyPem, ""},
+		{"chain out of order", leaf.pem, root.pem + intermediate.pem, leaf.keyPem, "not in order"},
+		{"key mismatch", leaf.pem, intermediate.pem, other.keyPem, "does not match"},
+		{"expiring", expiring.pem, intermediate.pem, expiring.keyPem, "within 30 days"},
+		{"chain in body", leaf.pem + intermediate.pem, "", leaf.keyPem, "only one certificate"},
+		{"not pem", "certificate", "", leaf.keyPem, "certificate_body is invalid"},
+		{"key in chain", leaf.pem, leaf.keyPem, leaf.keyPem, "must be CERTIFICATE"},
+		{"encrypted key", leaf.pem, "", encryptedKey, "encrypted private key"},
+		{"legacy encrypted key", leaf.pem, "", legacyEncryptedKey, "encrypted private key"},
+	}
+	for _, test := range tests {
+		certificate, err := validateCertificateBundle(test.body, test.chain, test.privateKey, now, 30)
+		if len(test.expectedErr) == 0 {
+			if err != nil {
+				t.Errorf("%s : unexpected error %v", test.name, err)
+			} else if certificate.Subject.CommonName != "www.example.com" {
+				t.Errorf("%s : unexpected leaf certificate %s", test.name, certificate.Subject.CommonName)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.expectedErr) {
+			t.Errorf("%s : expected error containing %q, got %v", test.name, test.expectedErr, err)
+		}
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/dns"
 	common "github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/dns2"
+	"github.com/antihax/optional"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"regexp"
@@ -70,44 +73,112 @@ func ResourceDnsRecord() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"record_destination": {
-							Type:             schema.TypeString,
-							Required:         true,
-							Description:      "DnsDomain Resource Destination",
-							ValidateDiagFunc: validateIpv4,
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "DnsDomain Resource Destination. IPv4 address for A, IPv6 address for AAAA, fully qualified domain name for CNAME and MX, text for TXT and SPF (quoted strings of at most 255 characters)",
 						},
 						"preference": {
 							Type:         schema.TypeInt,
 							Optional:     true,
-							Description:  "DnsDomain Resource Weight",
+							Description:  "DnsDomain Resource Weight. Required for MX",
 							ValidateFunc: validation.IntBetween(0, 65535),
 						},
 					},
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourceDnsRecordMappingDiff,
+			resourceDnsRecordCnameDiff,
+		),
 		Description: "Provides a DnsDomain resource.",
 	}
 }
 
-func validateIpv4(v interface{}, path cty.Path) diag.Diagnostics {
-	var diags diag.Diagnostics
-	// Get attribute key
-	attr := path[len(path)-1].(cty.GetAttrStep)
-	attrKey := attr.Name
+// resourceDnsRecordMappingDiff validates the record destinations by the record type
+func resourceDnsRecordMappingDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("dns_record_type") || !diff.NewValueKnown("dns_record_mapping") {
+		return nil
+	}
 
-	// Get value
-	value := v.(string)
+	dnsRecordType := diff.Get("dns_record_type").(string)
+	mappings := diff.Get("dns_record_mapping").(*schema.Set).List()
+	if len(mappings) > 1 && isSingleMappingRecordType(dnsRecordType) {
+		return fmt.Errorf("%s record can have only one dns_record_mapping", dnsRecordType)
+	}
 
-	// Check characters
-	if !regexp.MustCompile("^(25[0-5]|2[0-4]\\d|1\\d{2}|[1-9]\\d|[1-9])(\\.(25[0-5]|2[0-4]\\d|1\\d{2}|[1-9]\\d|\\d)){3}$").MatchString(value) {
-		diags = append(diags, diag.Diagnostic{
-			Severity:      diag.Error,
-			Summary:       fmt.Sprintf("Attribute %q: Supports only IPv4 format.", attrKey),
-			AttributePath: path,
-		})
+	for _, mapping := range mappings {
+		destination := mapping.(map[string]interface{})["record_destination"].(string)
+		if err := validateDnsRecordDestination(dnsRecordType, destination); err != nil {
+			return err
+		}
 	}
 
-	return diags
+	if dnsRecordType == "MX" && isMxPreferenceMissing(diff.GetRawConfig()) {
+		return fmt.Errorf("preference of dns_record_mapping is required for MX record")
+	}
+	return nil
+}
+
+// isMxPreferenceMissing checks the raw config, because an unset preference can not be told from 0 in the set
+func isMxPreferenceMissing(rawConfig cty.Value) bool {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return false
+	}
+	mappings := rawConfig.GetAttr("dns_record_mapping")
+	if mappings.IsNull() || !mappings.IsKnown() {
+		return false
+	}
+	for it := mappings.ElementIterator(); it.Next(); {
+		_, mapping := it.Element()
+		if mapping.IsNull() || !mapping.IsKnown() {
+			continue
+		}
+		if mapping.GetAttr("preference").IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceDnsRecordCnameDiff checks that a CNAME record does not share the name with other records of the domain
+func resourceDnsRecordCnameDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if len(diff.Id()) != 0 && !diff.HasChanges("dns_record_name", "dns_record_type") {
+		return nil
+	}
+	if !diff.NewValueKnown("dns_domain_id") || !diff.NewValueKnown("dns_record_name") || !diff.NewValueKnown("dns_record_type") {
+		return nil
+	}
+
+	inst := meta.(*client.Instance)
+	dnsDomainId := diff.Get("dns_domain_id").(string)
+	dnsRecordName := diff.Get("dns_record_name").(string)
+	dnsRecordType := diff.Get("dns_record_type").(string)
+
+	responses, _, err := inst.Client.Dns.GetDnsRecordList(ctx, dnsDomainId, &dns2.DnsOpenApiV2ControllerApiListDnsRecordOpts{
+		DnsRecordName: optional.NewString(dnsRecordName),
+		Page:          optional.NewInt32(0),
+		Size:          optional.NewInt32(10000),
+		Sort:          optional.Interface{},
+	})
+	if err != nil {
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return err
+	}
+
+	records := []zoneRecord{{Name: dnsRecordName, Type: dnsRecordType}}
+	for _, item := range responses.Contents {
+		if item.DnsRecordId == diff.Id() || item.DnsRecordName != dnsRecordName {
+			continue
+		}
+		if item.DnsState == common.DeletedState || item.DnsState == common.TerminatingState {
+			continue
+		}
+		records = append(records, zoneRecord{Name: item.DnsRecordName, Type: item.DnsRecordType})
+	}
+	return checkCnameExclusivity(records)
 }
 
 func validateDnsRecordName(rd *schema.ResourceData) diag.Diagnostics {
@@ -131,13 +202,13 @@ func validateDnsRecordName(rd *schema.ResourceData) diag.Diagnostics {
 	return nil
 }
 
-func convertDnsDomainResources(list common.HclListObject) ([]dns.DnsRecordMappingRequest, error) {
+func convertDnsDomainResources(dnsRecordType string, list common.HclListObject) ([]dns.DnsRecordMappingRequest, error) {
 	var result []dns.DnsRecordMappingRequest
 	for _, l := range list {
 		itemObject := l.(common.HclKeyValueObject)
 		info := dns.DnsRecordMappingRequest{}
 		if recordDestination, ok := itemObject["record_destination"]; ok {
-			info.RecordDestination = recordDestination.(string)
+			info.RecordDestination = normalizeDnsRecordDestination(dnsRecordType, recordDestination.(string))
 		}
 		if preference, ok := itemObject["preference"]; ok {
 			info.Preference = int32(preference.(int))
@@ -164,7 +235,7 @@ func resourceDnsRecordCreate(ctx context.Context, rd *schema.ResourceData, meta
 	dnsRecordName := rd.Get("dns_record_name").(string)
 	ttl := int32(rd.Get("ttl").(int))
 
-	dnsRecordMapping, err := convertDnsDomainResources(rd.Get("dns_record_mapping").(*schema.Set).List())
+	dnsRecordMapping, err := convertDnsDomainResources(dnsRecordType, rd.Get("dns_record_mapping").(*schema.Set).List())
 
 	createRequest := dns.CreateDnsRecordRequest{
 		DnsRecordType:    dnsRecordType,
@@ -214,11 +285,22 @@ func resourceDnsRecordRead(ctx context.Context, rd *schema.ResourceData, meta in
 	rd.Set("dns_record_type", info.DnsRecordType)
 	rd.Set("ttl", info.Ttl)
 
+	// keep the configured form of the destination, if it differs only in the trailing dot or the case
+	configured := make(map[string]string)
+	for _, mapping := range rd.Get("dns_record_mapping").(*schema.Set).List() {
+		destination := mapping.(map[string]interface{})["record_destination"].(string)
+		configured[normalizeDnsRecordDestination(info.DnsRecordType, destination)] = destination
+	}
+
 	var dnsRecordMapping []common.HclKeyValueObject
 
 	for _, recordMapInfo := range info.DnsRecordMapping {
+		recordDestination := recordMapInfo.RecordDestination
+		if destination, ok := configured[normalizeDnsRecordDestination(info.DnsRecordType, recordDestination)]; ok {
+			recordDestination = destination
+		}
 		dnsRecordMapping = append(dnsRecordMapping, common.HclKeyValueObject{
-			"record_destination": recordMapInfo.RecordDestination,
+			"record_destination": recordDestination,
 			"preference":         recordMapInfo.Preference,
 		})
 	}
@@ -243,7 +325,7 @@ func resourceDnsRecordUpdate(ctx context.Context, rd *schema.ResourceData, meta
 		for i, recordMapInfo := range dnsRecordMappingList {
 			r := recordMapInfo.(map[string]interface{})
 			if t, ok := r["record_destination"]; ok {
-				dnsRecordMapping[i].RecordDestination = t.(string)
+				dnsRecordMapping[i].RecordDestination = normalizeDnsRecordDestination(dnsRecordType, t.(string))
 			}
 			if v, ok := r["preference"]; ok {
 				dnsRecordMapping[i].Preference = int32(v.(int))
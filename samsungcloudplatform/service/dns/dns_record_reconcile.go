@@ -0,0 +1,122 @@
+package dns
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/dns"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+)
+
+// reconcileDnsRecords creates or updates the desired records of the domain, and deletes the owned records which are not desired.
+// Returns the ids of the desired records applied so far, even if it failed in the middle.
+func reconcileDnsRecords(ctx context.Context, inst *client.Instance, dnsDomainId string, desired []zoneRecord, isOwned func(key string) bool) (map[string]string, error) {
+	recordIds := make(map[string]string)
+
+	existing, existingIds, err := getDnsDomainZoneRecords(ctx, inst, dnsDomainId)
+	if err != nil {
+		return recordIds, err
+	}
+	existingRecords := make(map[string]zoneRecord)
+	for _, record := range existing {
+		existingRecords[record.key()] = record
+	}
+
+	desiredKeys := make(map[string]bool)
+	for _, record := range desired {
+		desiredKeys[record.key()] = true
+	}
+
+	// delete first, so that a CNAME can replace other records of the same name
+	var deleteIds []string
+	for key, recordId := range existingIds {
+		if !desiredKeys[key] && isOwned(key) {
+			deleteIds = append(deleteIds, recordId)
+		}
+	}
+	err = deleteDnsRecords(ctx, inst, dnsDomainId, deleteIds)
+	if err != nil {
+		return recordIds, err
+	}
+
+	var createdIds, updatedIds []string
+	for _, record := range desired {
+		mappings := make([]dns.DnsRecordMappingRequest, 0, len(record.Mappings))
+		for _, mapping := range record.Mappings {
+			mappings = append(mappings, dns.DnsRecordMappingRequest{
+				RecordDestination: normalizeDnsRecordDestination(record.Type, mapping.RecordDestination),
+				Preference:        mapping.Preference,
+			})
+		}
+
+		if recordId, ok := existingIds[record.key()]; ok {
+			recordIds[record.key()] = recordId
+			if existingRecords[record.key()].signature() == record.signature() {
+				continue
+			}
+			_, _, err = inst.Client.Dns.UpdateDnsRecord(ctx, dnsDomainId, recordId, dns.ChangeDnsRecordRequest{
+				DnsRecordType:    record.Type,
+				Ttl:              record.Ttl,
+				DnsRecordMapping: mappings,
+			})
+			if err != nil {
+				return recordIds, err
+			}
+			updatedIds = append(updatedIds, recordId)
+			continue
+		}
+
+		result, _, err := inst.Client.Dns.CreateDnsRecord(ctx, dnsDomainId, dns.CreateDnsRecordRequest{
+			DnsRecordType:    record.Type,
+			DnsRecordName:    record.Name,
+			Ttl:              record.Ttl,
+			DnsRecordMapping: mappings,
+		})
+		if err != nil {
+			return recordIds, err
+		}
+		recordIds[record.key()] = result.ResourceId
+		createdIds = append(createdIds, result.ResourceId)
+	}
+
+	// wait for server state
+	if len(createdIds) != 0 || len(updatedIds) != 0 {
+		time.Sleep(10 * time.Second)
+	}
+	for _, recordId := range createdIds {
+		err = waitForDnsRecordStatus(ctx, inst.Client, dnsDomainId, recordId, []string{"CREATING"}, []string{"ACTIVE"}, true)
+		if err != nil {
+			return recordIds, err
+		}
+	}
+	for _, recordId := range updatedIds {
+		err = waitForDnsRecordStatus(ctx, inst.Client, dnsDomainId, recordId, []string{"EDITING"}, []string{"ACTIVE"}, true)
+		if err != nil {
+			return recordIds, err
+		}
+	}
+	return recordIds, nil
+}
+
+func deleteDnsRecords(ctx context.Context, inst *client.Instance, dnsDomainId string, recordIds []string) error {
+	for _, recordId := range recordIds {
+		_, _, err := inst.Client.Dns.DeleteDnsRecord(ctx, dnsDomainId, recordId)
+		if err != nil && !common.IsDeleted(err) {
+			return err
+		}
+	}
+	if len(recordIds) == 0 {
+		return nil
+	}
+
+	time.Sleep(10 * time.Second)
+
+	for _, recordId := range recordIds {
+		err := waitForDnsRecordStatus(ctx, inst.Client, dnsDomainId, recordId, []string{"TERMINATING"}, []string{"DELETED"}, false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_dns_record_set", ResourceDnsRecordSet())
+}
+
+func ResourceDnsRecordSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDnsRecordSetApply,
+		ReadContext:   resourceDnsRecordSetRead,
+		UpdateContext: resourceDnsRecordSetApply,
+		DeleteContext: resourceDnsRecordSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceDnsRecordSetDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"dns_domain_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "DNS Domain Id",
+			},
+			"record": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "DNS records of the domain. Every record of the domain not listed here is deleted on apply.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dns_record_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "DNS Record Name (1 to 63, lowercase, number and -_.@). Use @ for the domain itself.",
+							ValidateFunc: validation.All(
+								validation.StringLenBetween(1, 63),
+								validation.StringMatch(regexp.MustCompile(`^[a-z\d@*_.-]*$`), "must contain only lowercase, number and -_.@"),
+							),
+						},
+						"dns_record_type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "DNS Record Type. One of A, TXT, CNAME, MX, AAAA, SPF",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(zoneSupportedRecordTypes, false)),
+						},
+						"ttl": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							Description:      "DNS TTL. (300 to 86400)",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(300, 86400)),
+						},
+						"dns_record_mapping": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Description: "DNS Record Mappings. Record Type CNAME, SPF, TXT can have only one record mapping. Record Type A, AAAA, MX can have 1 or more record mappings.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"record_destination": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "DnsDomain Resource Destination. IPv4 address for A, IPv6 address for AAAA, fully qualified domain name for CNAME and MX, text for TXT and SPF (quoted strings of at most 255 characters)",
+									},
+									"preference": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      0,
+										Description:  "DnsDomain Resource Weight",
+										ValidateFunc: validation.IntBetween(0, 65535),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"record_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "DNS record ids by <dns_record_name>/<dns_record_type>",
+			},
+		},
+		Description: "Provides all DNS records of a domain. Records created outside of the resource are removed on apply.",
+	}
+}
+
+func expandDnsRecordSet(set *schema.Set) []zoneRecord {
+	var records []zoneRecord
+	for _, item := range set.List() {
+		itemObject := item.(map[string]interface{})
+		record := zoneRecord{
+			Name: itemObject["dns_record_name"].(string),
+			Type: itemObject["dns_record_type"].(string),
+			Ttl:  int32(itemObject["ttl"].(int)),
+		}
+		for _, mapping := range itemObject["dns_record_mapping"].(*schema.Set).List() {
+			mappingObject := mapping.(map[string]interface{})
+			record.Mappings = append(record.Mappings, zoneRecordMapping{
+				RecordDestination: mappingObject["record_destination"].(string),
+				Preference:        int32(mappingObject["preference"].(int)),
+			})
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func flattenDnsRecordSet(records []zoneRecord) []common.HclKeyValueObject {
+	result := make([]common.HclKeyValueObject, 0, len(records))
+	for _, record := range records {
+		var mappings []common.HclKeyValueObject
+		for _, mapping := range record.Mappings {
+			mappings = append(mappings, common.HclKeyValueObject{
+				"record_destination": mapping.RecordDestination,
+				"preference":         int(mapping.Preference),
+			})
+		}
+		result = append(result, common.HclKeyValueObject{
+			"dns_record_name":    record.Name,
+			"dns_record_type":    record.Type,
+			"ttl":                int(record.Ttl),
+			"dns_record_mapping": mappings,
+		})
+	}
+	return result
+}
+
+func validateDnsRecordSet(records []zoneRecord) error {
+	keys := make(map[string]bool)
+	for _, record := range records {
+		if keys[record.key()] {
+			return fmt.Errorf("%s %s record is declared more than once. put all values in dns_record_mapping of a single record", record.Name, record.Type)
+		}
+		keys[record.key()] = true
+
+		if len(record.Mappings) > 1 && isSingleMappingRecordType(record.Type) {
+			return fmt.Errorf("%s %s record can have only one record mapping", record.Name, record.Type)
+		}
+		for _, mapping := range record.Mappings {
+			if err := validateDnsRecordDestination(record.Type, mapping.RecordDestination); err != nil {
+				return fmt.Errorf("%s %s record : %w", record.Name, record.Type, err)
+			}
+		}
+	}
+	return checkCnameExclusivity(records)
+}
+
+func resourceDnsRecordSetDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("record") {
+		return nil
+	}
+	records := expandDnsRecordSet(diff.Get("record").(*schema.Set))
+	if err := validateDnsRecordSet(records); err != nil {
+		return err
+	}
+	if diff.HasChange("record") {
+		return diff.SetNewComputed("record_ids")
+	}
+	return nil
+}
+
+func resourceDnsRecordSetApply(ctx context.Context, rd *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
+	var err error = nil
+	defer func() {
+		if err != nil {
+			diagnostics = diag.FromErr(err)
+		}
+	}()
+
+	inst := meta.(*client.Instance)
+	dnsDomainId := rd.Get("dns_domain_id").(string)
+
+	desired := expandDnsRecordSet(rd.Get("record").(*schema.Set))
+	err = validateDnsRecordSet(desired)
+	if err != nil {
+		return
+	}
+
+	// every record of the domain is owned by the record set
+	recordIds, err := reconcileDnsRecords(ctx, inst, dnsDomainId, desired, func(string) bool {
+		return true
+	})
+
+	// keep the records applied so far, even if one of them failed
+	rd.SetId(dnsDomainId)
+	rd.Set("record_ids", recordIds)
+	if err != nil {
+		return
+	}
+
+	return resourceDnsRecordSetRead(ctx, rd, meta)
+}
+
+func resourceDnsRecordSetRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	records, recordIds, err := getDnsDomainZoneRecords(ctx, inst, rd.Id())
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// keep the configured form of the destinations, if they differ only in the trailing dot or the case
+	configured := make(map[string]string)
+	for _, record := range expandDnsRecordSet(rd.Get("record").(*schema.Set)) {
+		for _, mapping := range record.Mappings {
+			configured[record.key()+" "+normalizeDnsRecordDestination(record.Type, mapping.RecordDestination)] = mapping.RecordDestination
+		}
+	}
+	for i, record := range records {
+		for j, mapping := range record.Mappings {
+			if destination, ok := configured[record.key()+" "+normalizeDnsRecordDestination(record.Type, mapping.RecordDestination)]; ok {
+				records[i].Mappings[j].RecordDestination = destination
+			}
+		}
+	}
+
+	// unmanaged records are read as well, so that they are shown as removed on the next plan
+	rd.Set("dns_domain_id", rd.Id())
+	rd.Set("record", flattenDnsRecordSet(records))
+	rd.Set("record_ids", recordIds)
+
+	return nil
+}
+
+func resourceDnsRecordSetDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	var recordIds []string
+	for _, recordId := range rd.Get("record_ids").(map[string]interface{}) {
+		recordIds = append(recordIds, recordId.(string))
+	}
+
+	err := deleteDnsRecords(ctx, inst, rd.Id(), recordIds)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_dns_records_from_zone_file", ResourceDnsRecordsFromZoneFile())
+}
+
+func ResourceDnsRecordsFromZoneFile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDnsRecordsFromZoneFileApply,
+		ReadContext:   resourceDnsRecordsFromZoneFileRead,
+		UpdateContext: resourceDnsRecordsFromZoneFileApply,
+		DeleteContext: resourceDnsRecordsFromZoneFileDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDnsRecordsFromZoneFileImport,
+		},
+		CustomizeDiff: resourceDnsRecordsFromZoneFileDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"dns_domain_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "DNS Domain Id",
+			},
+			"zone_file": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "RFC 1035 zone file of the domain. SOA and apex NS records are ignored. Supported record types are A, AAAA, CNAME, MX, TXT and SPF.",
+			},
+			"authoritative": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, records of the domain which are not in the zone file are deleted. Otherwise only the records created from the zone file are managed.",
+			},
+			"records": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "DNS records of the domain managed by the zone file",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dns_record_name": {Type: schema.TypeString, Computed: true, Description: "DNS Record Name"},
+						"dns_record_type": {Type: schema.TypeString, Computed: true, Description: "DNS Record Type"},
+						"ttl":             {Type: schema.TypeInt, Computed: true, Description: "DNS TTL"},
+						"dns_record_mapping": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "DNS Record Mappings",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"record_destination": {Type: schema.TypeString, Computed: true, Description: "DnsDomain Resource Destination"},
+									"preference":         {Type: schema.TypeInt, Computed: true, Description: "DnsDomain Resource Weight"},
+								},
+							},
+						},
+					},
+				},
+			},
+			"record_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "DNS record ids by <dns_record_name>/<dns_record_type>",
+			},
+		},
+		Description: "Provides DNS records of a domain reconciled with a zone file.",
+	}
+}
+
+func flattenZoneRecords(records []zoneRecord) []common.HclKeyValueObject {
+	sorted := append([]zoneRecord{}, records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].key() < sorted[j].key()
+	})
+
+	result := make([]common.HclKeyValueObject, 0, len(sorted))
+	for _, record := range sorted {
+		mappings := append([]zoneRecordMapping{}, record.Mappings...)
+		sort.SliceStable(mappings, func(i, j int) bool {
+			if mappings[i].Preference != mappings[j].Preference {
+				return mappings[i].Preference < mappings[j].Preference
+			}
+			return mappings[i].RecordDestination < mappings[j].RecordDestination
+		})
+
+		var mappingList []common.HclKeyValueObject
+		for _, mapping := range mappings {
+			mappingList = append(mappingList, common.HclKeyValueObject{
+				"record_destination": mapping.RecordDestination,
+				"preference":         int(mapping.Preference),
+			})
+		}
+		result = append(result, common.HclKeyValueObject{
+			"dns_record_name":    record.Name,
+			"dns_record_type":    record.Type,
+			"ttl":                int(record.Ttl),
+			"dns_record_mapping": mappingList,
+		})
+	}
+	return result
+}
+
+func expandZoneRecords(list []interface{}) []zoneRecord {
+	var records []zoneRecord
+	for _, item := range list {
+		itemObject := item.(map[string]interface{})
+		record := zoneRecord{
+			Name: itemObject["dns_record_name"].(string),
+			Type: itemObject["dns_record_type"].(string),
+			Ttl:  int32(itemObject["ttl"].(int)),
+		}
+		for _, mapping := range itemObject["dns_record_mapping"].([]interface{}) {
+			mappingObject := mapping.(map[string]interface{})
+			record.Mappings = append(record.Mappings, zoneRecordMapping{
+				RecordDestination: mappingObject["record_destination"].(string),
+				Preference:        int32(mappingObject["preference"].(int)),
+			})
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func zoneRecordsEqual(a []zoneRecord, b []zoneRecord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	signatures := make(map[string]bool)
+	for _, record := range a {
+		signatures[record.signature()] = true
+	}
+	for _, record := range b {
+		if !signatures[record.signature()] {
+			return false
+		}
+	}
+	return true
+}
+
+func parseZoneFileOfDomain(ctx context.Context, inst *client.Instance, dnsDomainId string, zoneFile string) ([]zoneRecord, error) {
+	domainInfo, _, err := inst.Client.Dns.GetDnsDomainDetail(ctx, dnsDomainId)
+	if err != nil {
+		return nil, err
+	}
+	records, err := parseZoneFile(zoneFile, domainInfo.DnsDomainName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse zone file : %w", err)
+	}
+	return records, nil
+}
+
+// resourceDnsRecordsFromZoneFileDiff parses the zone file on plan, and compares it with the records of the domain
+func resourceDnsRecordsFromZoneFileDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("zone_file") || !diff.NewValueKnown("dns_domain_id") {
+		if err := diff.SetNewComputed("records"); err != nil {
+			return err
+		}
+		return diff.SetNewComputed("record_ids")
+	}
+
+	inst := meta.(*client.Instance)
+	desired, err := parseZoneFileOfDomain(ctx, inst, diff.Get("dns_domain_id").(string), diff.Get("zone_file").(string))
+	if err != nil {
+		return err
+	}
+
+	current := expandZoneRecords(diff.Get("records").([]interface{}))
+	if len(diff.Id()) != 0 && zoneRecordsEqual(current, desired) {
+		return nil
+	}
+	if err = diff.SetNew("records", flattenZoneRecords(desired)); err != nil {
+		return err
+	}
+	return diff.SetNewComputed("record_ids")
+}
+
+func resourceDnsRecordsFromZoneFileApply(ctx context.Context, rd *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
+	var err error = nil
+	defer func() {
+		if err != nil {
+			diagnostics = diag.FromErr(err)
+		}
+	}()
+
+	inst := meta.(*client.Instance)
+	dnsDomainId := rd.Get("dns_domain_id").(string)
+
+	desired, err := parseZoneFileOfDomain(ctx, inst, dnsDomainId, rd.Get("zone_file").(string))
+	if err != nil {
+		return
+	}
+
+	authoritative := rd.Get("authoritative").(bool)
+	managedIds := rd.Get("record_ids").(map[string]interface{})
+	recordIds, err := reconcileDnsRecords(ctx, inst, dnsDomainId, desired, func(key string) bool {
+		_, ok := managedIds[key]
+		return authoritative || ok
+	})
+
+	// keep the records applied so far, even if one of them failed
+	rd.SetId(dnsDomainId)
+	rd.Set("record_ids", recordIds)
+	if err != nil {
+		return
+	}
+
+	return resourceDnsRecordsFromZoneFileRead(ctx, rd, meta)
+}
+
+func resourceDnsRecordsFromZoneFileRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	existing, existingIds, err := getDnsDomainZoneRecords(ctx, inst, rd.Id())
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	authoritative := rd.Get("authoritative").(bool)
+	managedIds := rd.Get("record_ids").(map[string]interface{})
+
+	var records []zoneRecord
+	recordIds := make(map[string]string)
+	for _, record := range existing {
+		if _, ok := managedIds[record.key()]; !ok && !authoritative {
+			continue
+		}
+		records = append(records, record)
+		recordIds[record.key()] = existingIds[record.key()]
+	}
+
+	rd.Set("dns_domain_id", rd.Id())
+	rd.Set("records", flattenZoneRecords(records))
+	rd.Set("record_ids", recordIds)
+
+	return nil
+}
+
+func resourceDnsRecordsFromZoneFileDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	var recordIds []string
+	for _, recordId := range rd.Get("record_ids").(map[string]interface{}) {
+		recordIds = append(recordIds, recordId.(string))
+	}
+
+	err := deleteDnsRecords(ctx, inst, rd.Id(), recordIds)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// resourceDnsRecordsFromZoneFileImport adopts all records of the domain, and renders them as the zone file
+func resourceDnsRecordsFromZoneFileImport(ctx context.Context, rd *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	inst := meta.(*client.Instance)
+
+	domainInfo, _, err := inst.Client.Dns.GetDnsDomainDetail(ctx, rd.Id())
+	if err != nil {
+		return nil, err
+	}
+	records, recordIds, err := getDnsDomainZoneRecords(ctx, inst, rd.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	rd.Set("dns_domain_id", rd.Id())
+	rd.Set("zone_file", renderZoneFile(domainInfo.DnsDomainName, records))
+	rd.Set("authoritative", false)
+	rd.Set("record_ids", recordIds)
+
+	return []*schema.ResourceData{rd}, nil
+}
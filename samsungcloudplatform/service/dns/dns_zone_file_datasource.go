@@ -0,0 +1,104 @@
+package dns
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/dns2"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func init() {
+	samsungcloudplatform.RegisterDataSource("samsungcloudplatform_dns_zone_file", DatasourceDnsZoneFile())
+}
+
+func DatasourceDnsZoneFile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDnsZoneFileRead,
+		Schema: map[string]*schema.Schema{
+			"dns_domain_id":   {Type: schema.TypeString, Required: true, Description: "DNS Domain Id"},
+			"dns_domain_name": {Type: schema.TypeString, Computed: true, Description: "DNS Domain Name"},
+			"zone_file":       {Type: schema.TypeString, Computed: true, Description: "A, AAAA, CNAME, MX, TXT and SPF records of the domain rendered as an RFC 1035 zone file"},
+			"record_count":    {Type: schema.TypeInt, Computed: true, Description: "Number of DNS records in the zone file"},
+		},
+		Description: "Provides all DNS records of a domain as a zone file.",
+	}
+}
+
+func dataSourceDnsZoneFileRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	dnsDomainId := rd.Get("dns_domain_id").(string)
+	domainInfo, _, err := inst.Client.Dns.GetDnsDomainDetail(ctx, dnsDomainId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	records, _, err := getDnsDomainZoneRecords(ctx, inst, dnsDomainId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rd.SetId(dnsDomainId)
+	rd.Set("dns_domain_name", domainInfo.DnsDomainName)
+	rd.Set("zone_file", renderZoneFile(domainInfo.DnsDomainName, records))
+	rd.Set("record_count", len(records))
+
+	return nil
+}
+
+// getDnsDomainZoneRecords returns the records of the domain with the supported record types and the record ids by the record key
+func getDnsDomainZoneRecords(ctx context.Context, inst *client.Instance, dnsDomainId string) ([]zoneRecord, map[string]string, error) {
+	responses, _, err := inst.Client.Dns.GetDnsRecordList(ctx, dnsDomainId, &dns2.DnsOpenApiV2ControllerApiListDnsRecordOpts{
+		Page: optional.NewInt32(0),
+		Size: optional.NewInt32(10000),
+		Sort: optional.Interface{},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []zoneRecord
+	recordIds := make(map[string]string)
+	for _, item := range responses.Contents {
+		if len(item.DnsRecordId) == 0 || item.DnsState == common.DeletedState || item.DnsState == common.TerminatingState {
+			continue
+		}
+		// SOA, NS and other record types are kept by the service and are never managed
+		if !isSupportedZoneRecordType(strings.ToUpper(item.DnsRecordType)) {
+			continue
+		}
+
+		// preference of the mapping is only in the detail
+		info, _, err := inst.Client.Dns.GetDnsRecordDetail(ctx, dnsDomainId, item.DnsRecordId)
+		if err != nil {
+			if common.IsDeleted(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		record := zoneRecord{
+			Name: info.DnsRecordName,
+			Type: strings.ToUpper(info.DnsRecordType),
+			Ttl:  info.Ttl,
+		}
+		if len(record.Name) == 0 {
+			record.Name = zoneApexName
+		}
+		for _, mapping := range info.DnsRecordMapping {
+			record.Mappings = append(record.Mappings, zoneRecordMapping{
+				RecordDestination: mapping.RecordDestination,
+				Preference:        mapping.Preference,
+			})
+		}
+		records = append(records, record)
+		recordIds[record.key()] = item.DnsRecordId
+	}
+	return records, recordIds, nil
+}
@@ -0,0 +1,138 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// dnsHostnameMaxLength is the maximum length of a domain name in the text form without the trailing dot
+const dnsHostnameMaxLength = 253
+
+var dnsLabelRegexp = regexp.MustCompile(`^[a-zA-Z\d_]([a-zA-Z\d_-]{0,61}[a-zA-Z\d_])?$`)
+
+// isDnsHostnameRecordType returns whether the destination of the record type is a domain name
+func isDnsHostnameRecordType(recordType string) bool {
+	return recordType == "CNAME" || recordType == "MX"
+}
+
+// isSingleMappingRecordType returns whether the record type can have only one record mapping
+func isSingleMappingRecordType(recordType string) bool {
+	return recordType == "CNAME" || recordType == "TXT" || recordType == "SPF"
+}
+
+// normalizeDnsRecordDestination removes the trailing dot of a domain name, which the service does not keep,
+// and puts the text of TXT and SPF records into quoted strings of at most 255 characters
+func normalizeDnsRecordDestination(recordType string, destination string) string {
+	if isDnsHostnameRecordType(recordType) {
+		return strings.ToLower(strings.TrimSuffix(destination, "."))
+	}
+	if recordType == "TXT" || recordType == "SPF" {
+		chunks, err := splitTxtStrings(destination)
+		if err != nil {
+			return destination
+		}
+		return quoteZoneText(chunks)
+	}
+	return destination
+}
+
+func validateDnsHostname(name string) error {
+	name = strings.TrimSuffix(name, ".")
+	if len(name) == 0 {
+		return fmt.Errorf("domain name is empty")
+	}
+	if len(name) > dnsHostnameMaxLength {
+		return fmt.Errorf("domain name %s is longer than %d characters", name, dnsHostnameMaxLength)
+	}
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("domain name %s must be fully qualified", name)
+	}
+	for _, label := range labels {
+		if !dnsLabelRegexp.MatchString(label) {
+			return fmt.Errorf("domain name %s has an invalid label %q. labels must be 1 to 63 letters, digits, - or _, and must not start or end with -", name, label)
+		}
+	}
+	return nil
+}
+
+// splitTxtStrings splits a TXT value into character-strings.
+// A value starting with a quote is a list of quoted strings. (e.g. "first" "second")
+func splitTxtStrings(value string) ([]string, error) {
+	if !strings.HasPrefix(strings.TrimSpace(value), `"`) {
+		return []string{value}, nil
+	}
+
+	lines, err := splitZoneLines(value)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, line := range lines {
+		for _, token := range line.Tokens {
+			if !token.Quoted {
+				return nil, fmt.Errorf("%q must be quoted", token.Value)
+			}
+			result = append(result, token.Value)
+		}
+	}
+	return result, nil
+}
+
+// validateDnsRecordDestination validates the record destination by the record type
+func validateDnsRecordDestination(recordType string, destination string) error {
+	switch recordType {
+	case "A":
+		ip := net.ParseIP(destination)
+		if ip == nil || ip.To4() == nil || strings.Contains(destination, ":") {
+			return fmt.Errorf("destination of A record must be an IPv4 address : %s", destination)
+		}
+	case "AAAA":
+		ip := net.ParseIP(destination)
+		if ip == nil || ip.To4() != nil {
+			return fmt.Errorf("destination of AAAA record must be an IPv6 address : %s", destination)
+		}
+	case "CNAME", "MX":
+		if err := validateDnsHostname(destination); err != nil {
+			return fmt.Errorf("destination of %s record is invalid : %w", recordType, err)
+		}
+	case "TXT", "SPF":
+		if len(destination) == 0 {
+			return fmt.Errorf("destination of %s record is empty", recordType)
+		}
+		chunks, err := splitTxtStrings(destination)
+		if err != nil {
+			return fmt.Errorf("destination of %s record is invalid : %w", recordType, err)
+		}
+		for _, chunk := range chunks {
+			if len(chunk) > txtStringMaxLength {
+				return fmt.Errorf("destination of %s record has a string longer than %d characters. split it into quoted strings such as \"first\" \"second\"", recordType, txtStringMaxLength)
+			}
+		}
+		if recordType == "SPF" && !strings.HasPrefix(strings.Join(chunks, ""), "v=spf1") {
+			return fmt.Errorf("destination of SPF record must start with v=spf1")
+		}
+	}
+	return nil
+}
+
+// checkCnameExclusivity checks that a name with a CNAME record has no other records
+func checkCnameExclusivity(records []zoneRecord) error {
+	types := make(map[string][]string)
+	for _, record := range records {
+		types[record.Name] = append(types[record.Name], record.Type)
+	}
+	for name, recordTypes := range types {
+		if len(recordTypes) < 2 {
+			continue
+		}
+		for _, recordType := range recordTypes {
+			if recordType == "CNAME" {
+				return fmt.Errorf("%s has a CNAME record, so it can not have other records (%s)", name, strings.Join(recordTypes, ", "))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package dns
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateDnsRecordDestination(t *testing.T) {
+	cases := []struct {
+		recordType  string
+		destination string
+		valid       bool
+	}{
+		{"A", "192.168.0.1", true},
+		{"A", "2001:db8::1", false},
+		{"A", "::ffff:192.168.0.1", false},
+		{"AAAA", "2001:db8::1", true},
+		{"AAAA", "192.168.0.1", false},
+		{"CNAME", "www.example.com.", true},
+		{"CNAME", "www", false},
+		{"CNAME", "-www.example.com", false},
+		{"MX", "mail.example.com", true},
+		{"MX", "mail..example.com", false},
+		{"TXT", "hello world", true},
+		{"TXT", strings.Repeat("a", 256), false},
+		{"TXT", `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("b", 10) + `"`, true},
+		{"TXT", `"first" second`, false},
+		{"SPF", "v=spf1 mx -all", true},
+		{"SPF", "mx -all", false},
+	}
+
+	for _, c := range cases {
+		err := validateDnsRecordDestination(c.recordType, c.destination)
+		if (err == nil) != c.valid {
+			t.Errorf("%s %s : expected valid %v but %v", c.recordType, c.destination, c.valid, err)
+		}
+	}
+}
+
+func TestCheckCnameExclusivity(t *testing.T) {
+	if err := checkCnameExclusivity([]zoneRecord{{Name: "www", Type: "CNAME"}, {Name: "@", Type: "A"}, {Name: "@", Type: "MX"}}); err != nil {
+		t.Error(err)
+	}
+	if err := checkCnameExclusivity([]zoneRecord{{Name: "www", Type: "CNAME"}, {Name: "www", Type: "TXT"}}); err == nil {
+		t.Error("CNAME with other records should not be allowed")
+	}
+}
+
+func TestNormalizeDnsRecordDestination(t *testing.T) {
+	if normalizeDnsRecordDestination("CNAME", "WWW.Example.com.") != "www.example.com" {
+		t.Error("trailing dot of CNAME should be removed")
+	}
+	if normalizeDnsRecordDestination("TXT", "text.") != `"text."` {
+		t.Error("TXT should be quoted")
+	}
+	if normalizeDnsRecordDestination("TXT", `"first"  "second"`) != `"first" "second"` {
+		t.Error("quoted strings of TXT should be kept")
+	}
+}
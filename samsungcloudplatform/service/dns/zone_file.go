@@ -0,0 +1,535 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// zoneApexName is the record name of the domain itself
+const zoneApexName = "@"
+
+// txtStringMaxLength is the maximum length of a single character-string of TXT rdata (RFC 1035 3.3)
+const txtStringMaxLength = 255
+
+var zoneSupportedRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "TXT", "SPF"}
+
+type zoneRecordMapping struct {
+	RecordDestination string
+	Preference        int32
+}
+
+// zoneRecord is a set of resource records with the same name and type, which is a single dns record of the service
+type zoneRecord struct {
+	Name     string
+	Type     string
+	Ttl      int32
+	Mappings []zoneRecordMapping
+}
+
+func (r zoneRecord) key() string {
+	return r.Name + "/" + r.Type
+}
+
+// signature returns the comparable form of the record regardless of the mapping order
+func (r zoneRecord) signature() string {
+	var mappings []string
+	for _, mapping := range r.Mappings {
+		destination := normalizeDnsRecordDestination(r.Type, mapping.RecordDestination)
+		mappings = append(mappings, fmt.Sprintf("%d %s", mapping.Preference, destination))
+	}
+	sort.Strings(mappings)
+	return fmt.Sprintf("%s %d %s", r.key(), r.Ttl, strings.Join(mappings, "\n"))
+}
+
+type zoneToken struct {
+	Value  string
+	Quoted bool
+}
+
+type zoneLine struct {
+	Number   int
+	Indented bool
+	Tokens   []zoneToken
+}
+
+// splitZoneLines splits the zone file into logical lines.
+// Comments are removed and lines in parentheses are joined.
+func splitZoneLines(content string) ([]zoneLine, error) {
+	var lines []zoneLine
+	var current zoneLine
+	var token strings.Builder
+	inToken, inQuote := false, false
+	parenDepth := 0
+	lineNumber := 1
+	lineStart := true
+	current.Number = lineNumber
+
+	flushToken := func(quoted bool) {
+		if inToken || quoted {
+			current.Tokens = append(current.Tokens, zoneToken{Value: token.String(), Quoted: quoted})
+		}
+		token.Reset()
+		inToken = false
+	}
+
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if inQuote {
+			switch r {
+			case '\\':
+				value, length, err := parseZoneEscape(runes[i+1:])
+				if err != nil {
+					return nil, fmt.Errorf("line %d : %w", lineNumber, err)
+				}
+				token.WriteString(value)
+				i += length
+			case '"':
+				inQuote = false
+				flushToken(true)
+			case '\n':
+				return nil, fmt.Errorf("line %d : unterminated quoted string", lineNumber)
+			default:
+				token.WriteRune(r)
+			}
+			continue
+		}
+
+		if lineStart && len(current.Tokens) == 0 && !inToken {
+			current.Indented = r == ' ' || r == '\t'
+			lineStart = false
+		}
+
+		switch {
+		case r == '\n':
+			flushToken(false)
+			lineNumber++
+			if parenDepth == 0 {
+				if len(current.Tokens) != 0 {
+					lines = append(lines, current)
+				}
+				current = zoneLine{Number: lineNumber}
+				lineStart = true
+			}
+		case r == ';':
+			// comment until the end of line, the newline is handled in the next iteration
+			flushToken(false)
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		case r == '"':
+			flushToken(false)
+			inQuote = true
+		case r == '(':
+			flushToken(false)
+			parenDepth++
+		case r == ')':
+			flushToken(false)
+			if parenDepth == 0 {
+				return nil, fmt.Errorf("line %d : unbalanced parenthesis", lineNumber)
+			}
+			parenDepth--
+		case unicode.IsSpace(r):
+			flushToken(false)
+		case r == '\\':
+			value, length, err := parseZoneEscape(runes[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d : %w", lineNumber, err)
+			}
+			token.WriteString(value)
+			inToken = true
+			i += length
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	if inQuote {
+		return nil, fmt.Errorf("line %d : unterminated quoted string", lineNumber)
+	}
+	if parenDepth != 0 {
+		return nil, fmt.Errorf("line %d : unbalanced parenthesis", lineNumber)
+	}
+	flushToken(false)
+	if len(current.Tokens) != 0 {
+		lines = append(lines, current)
+	}
+	return lines, nil
+}
+
+// parseZoneEscape parses the escape sequence following a backslash (\X or \DDD)
+func parseZoneEscape(runes []rune) (string, int, error) {
+	if len(runes) == 0 {
+		return "", 0, fmt.Errorf("invalid escape at the end of file")
+	}
+	if len(runes) >= 3 && unicode.IsDigit(runes[0]) && unicode.IsDigit(runes[1]) && unicode.IsDigit(runes[2]) {
+		value, _ := strconv.Atoi(string(runes[:3]))
+		if value > 255 {
+			return "", 0, fmt.Errorf("invalid escape \\%s", string(runes[:3]))
+		}
+		// \DDD is a single octet, which is not a unicode code point
+		return string([]byte{byte(value)}), 3, nil
+	}
+	return string(runes[0]), 1, nil
+}
+
+// parseZoneTtl parses a ttl in seconds or with units. (e.g. 3600, 1h, 1h30m)
+func parseZoneTtl(value string) (int32, bool) {
+	if len(value) == 0 {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 32); err == nil {
+		return int32(seconds), seconds >= 0
+	}
+
+	units := map[rune]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var total, number int64
+	hasNumber := false
+	for _, r := range strings.ToLower(value) {
+		if unicode.IsDigit(r) {
+			number = number*10 + int64(r-'0')
+			hasNumber = true
+			continue
+		}
+		unit, ok := units[r]
+		if !ok || !hasNumber {
+			return 0, false
+		}
+		total += number * unit
+		number, hasNumber = 0, false
+	}
+	if hasNumber || total > 2147483647 {
+		return 0, false
+	}
+	return int32(total), true
+}
+
+func toFqdn(name string, origin string) string {
+	name = strings.ToLower(name)
+	if name == zoneApexName {
+		return origin
+	}
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "." + origin
+}
+
+func toZoneOrigin(domainName string) string {
+	return strings.ToLower(strings.TrimSuffix(domainName, ".")) + "."
+}
+
+// toRecordName converts the owner name of the zone file into the dns record name of the service
+func toRecordName(owner string, origin string) (string, error) {
+	fqdn := toFqdn(owner, origin)
+	if fqdn == origin {
+		return zoneApexName, nil
+	}
+	if !strings.HasSuffix(fqdn, "."+origin) {
+		return "", fmt.Errorf("%s is out of zone %s", owner, origin)
+	}
+	return strings.TrimSuffix(fqdn, "."+origin), nil
+}
+
+func isSupportedZoneRecordType(recordType string) bool {
+	for _, t := range zoneSupportedRecordTypes {
+		if t == recordType {
+			return true
+		}
+	}
+	return false
+}
+
+// parseZoneFile parses the RFC 1035 zone file of the domain into dns records.
+// SOA and apex NS records are managed by the service and are ignored.
+func parseZoneFile(content string, domainName string) ([]zoneRecord, error) {
+	lines, err := splitZoneLines(content)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := toZoneOrigin(domainName)
+	zoneOrigin := origin
+	var defaultTtl, lastTtl int32 = -1, -1
+	lastOwner := ""
+
+	records := make(map[string]*zoneRecord)
+	var order []string
+
+	for _, line := range lines {
+		tokens := line.Tokens
+
+		if !line.Indented && strings.HasPrefix(tokens[0].Value, "$") && !tokens[0].Quoted {
+			directive := strings.ToUpper(tokens[0].Value)
+			switch directive {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d : $ORIGIN requires a domain name", line.Number)
+				}
+				origin = toFqdn(tokens[1].Value, origin)
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d : $TTL requires a ttl", line.Number)
+				}
+				ttl, ok := parseZoneTtl(tokens[1].Value)
+				if !ok {
+					return nil, fmt.Errorf("line %d : invalid ttl %s", line.Number, tokens[1].Value)
+				}
+				defaultTtl = ttl
+			default:
+				return nil, fmt.Errorf("line %d : %s is not supported", line.Number, directive)
+			}
+			continue
+		}
+
+		owner := lastOwner
+		if !line.Indented {
+			owner = toFqdn(tokens[0].Value, origin)
+			tokens = tokens[1:]
+		}
+		if len(owner) == 0 {
+			return nil, fmt.Errorf("line %d : owner name is missing", line.Number)
+		}
+		lastOwner = owner
+
+		ttl := int32(-1)
+		// ttl and class can be in any order
+	ttlAndClass:
+		for len(tokens) != 0 && !tokens[0].Quoted {
+			value, isTtl := parseZoneTtl(tokens[0].Value)
+			switch class := strings.ToUpper(tokens[0].Value); {
+			case isTtl && ttl < 0:
+				ttl = value
+			case class == "IN":
+			case class == "CH" || class == "HS" || class == "CS":
+				return nil, fmt.Errorf("line %d : only IN class is supported", line.Number)
+			default:
+				break ttlAndClass
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d : record type is missing", line.Number)
+		}
+		if ttl < 0 {
+			ttl = defaultTtl
+		}
+		if ttl < 0 {
+			ttl = lastTtl
+		}
+		if ttl < 0 {
+			return nil, fmt.Errorf("line %d : ttl is missing and $TTL is not defined", line.Number)
+		}
+		lastTtl = ttl
+
+		recordType := strings.ToUpper(tokens[0].Value)
+		rdata := tokens[1:]
+
+		if recordType == "SOA" {
+			continue
+		}
+		if recordType == "NS" && owner == zoneOrigin {
+			continue
+		}
+		if !isSupportedZoneRecordType(recordType) {
+			return nil, fmt.Errorf("line %d : record type %s is not supported. supported types are %s", line.Number, recordType, strings.Join(zoneSupportedRecordTypes, ", "))
+		}
+
+		name, err := toRecordName(owner, zoneOrigin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d : %w", line.Number, err)
+		}
+
+		mapping, err := parseZoneRdata(recordType, rdata, origin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d : %w", line.Number, err)
+		}
+
+		key := zoneRecord{Name: name, Type: recordType}.key()
+		record, ok := records[key]
+		if !ok {
+			record = &zoneRecord{Name: name, Type: recordType, Ttl: ttl}
+			records[key] = record
+			order = append(order, key)
+		}
+		if record.Ttl != ttl {
+			return nil, fmt.Errorf("line %d : ttl of %s %s records must be the same", line.Number, owner, recordType)
+		}
+		duplicated := false
+		for _, m := range record.Mappings {
+			if m == mapping {
+				duplicated = true
+			}
+		}
+		if !duplicated {
+			record.Mappings = append(record.Mappings, mapping)
+		}
+		// several TXT or SPF values of a name, such as an SPF policy and a verification token, are the mappings of a single record
+		if len(record.Mappings) > 1 && recordType == "CNAME" {
+			return nil, fmt.Errorf("line %d : %s %s can have only one record", line.Number, owner, recordType)
+		}
+	}
+
+	result := make([]zoneRecord, 0, len(order))
+	for _, key := range order {
+		result = append(result, *records[key])
+	}
+	if err = checkCnameExclusivity(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func parseZoneRdata(recordType string, rdata []zoneToken, origin string) (zoneRecordMapping, error) {
+	switch recordType {
+	case "A", "AAAA":
+		if len(rdata) != 1 {
+			return zoneRecordMapping{}, fmt.Errorf("%s record requires an ip address", recordType)
+		}
+		ip := net.ParseIP(rdata[0].Value)
+		if ip == nil || (recordType == "A") != (ip.To4() != nil) {
+			return zoneRecordMapping{}, fmt.Errorf("invalid %s record address %s", recordType, rdata[0].Value)
+		}
+		return zoneRecordMapping{RecordDestination: ip.String()}, nil
+	case "CNAME":
+		if len(rdata) != 1 {
+			return zoneRecordMapping{}, fmt.Errorf("CNAME record requires a domain name")
+		}
+		destination := strings.TrimSuffix(toFqdn(rdata[0].Value, origin), ".")
+		if err := validateDnsHostname(destination); err != nil {
+			return zoneRecordMapping{}, err
+		}
+		return zoneRecordMapping{RecordDestination: destination}, nil
+	case "MX":
+		if len(rdata) != 2 {
+			return zoneRecordMapping{}, fmt.Errorf("MX record requires a preference and a domain name")
+		}
+		preference, err := strconv.ParseUint(rdata[0].Value, 10, 16)
+		if err != nil {
+			return zoneRecordMapping{}, fmt.Errorf("invalid MX preference %s", rdata[0].Value)
+		}
+		destination := strings.TrimSuffix(toFqdn(rdata[1].Value, origin), ".")
+		if err := validateDnsHostname(destination); err != nil {
+			return zoneRecordMapping{}, err
+		}
+		return zoneRecordMapping{
+			RecordDestination: destination,
+			Preference:        int32(preference),
+		}, nil
+	default:
+		// TXT, SPF : character-strings are kept as quoted strings, which is the form of samsungcloudplatform_dns_record
+		if len(rdata) == 0 {
+			return zoneRecordMapping{}, fmt.Errorf("%s record requires a text", recordType)
+		}
+		var texts []string
+		for _, token := range rdata {
+			texts = append(texts, token.Value)
+		}
+		return zoneRecordMapping{RecordDestination: quoteZoneText(texts)}, nil
+	}
+}
+
+// quoteZoneText renders character-strings as quoted strings.
+// Strings longer than 255 bytes are split without cutting a multi-byte character,
+// and control characters and bytes which are not valid UTF-8 are written as \DDD.
+func quoteZoneText(texts []string) string {
+	var result []string
+	for _, text := range texts {
+		for first := true; first || len(text) != 0; first = false {
+			chunk := text
+			if len(chunk) > txtStringMaxLength {
+				end := txtStringMaxLength
+				for end > 0 && !utf8.RuneStart(chunk[end]) {
+					end--
+				}
+				if end == 0 {
+					end = txtStringMaxLength
+				}
+				chunk = chunk[:end]
+			}
+			text = text[len(chunk):]
+			result = append(result, `"`+escapeZoneText(chunk)+`"`)
+		}
+	}
+	return strings.Join(result, " ")
+}
+
+func escapeZoneText(text string) string {
+	var builder strings.Builder
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == '\\' || r == '"':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case (r == utf8.RuneError && size == 1) || r < 0x20 || r == 0x7f:
+			builder.WriteString(fmt.Sprintf("\\%03d", text[i]))
+		default:
+			builder.WriteString(text[i : i+size])
+		}
+		i += size
+	}
+	return builder.String()
+}
+
+func renderZoneDestination(destination string) string {
+	if strings.HasSuffix(destination, ".") {
+		return destination
+	}
+	return destination + "."
+}
+
+// renderZoneFile renders the dns records of the domain as an RFC 1035 zone file
+func renderZoneFile(domainName string, records []zoneRecord) string {
+	sorted := append([]zoneRecord{}, records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			// apex first
+			if sorted[i].Name == zoneApexName || sorted[j].Name == zoneApexName {
+				return sorted[i].Name == zoneApexName
+			}
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("$ORIGIN %s\n", toZoneOrigin(domainName)))
+	for _, record := range sorted {
+		name := record.Name
+		if len(name) == 0 {
+			name = zoneApexName
+		}
+
+		mappings := append([]zoneRecordMapping{}, record.Mappings...)
+		sort.SliceStable(mappings, func(i, j int) bool {
+			if mappings[i].Preference != mappings[j].Preference {
+				return mappings[i].Preference < mappings[j].Preference
+			}
+			return mappings[i].RecordDestination < mappings[j].RecordDestination
+		})
+
+		for _, mapping := range mappings {
+			var rdata string
+			switch record.Type {
+			case "CNAME":
+				rdata = renderZoneDestination(mapping.RecordDestination)
+			case "MX":
+				rdata = fmt.Sprintf("%d %s", mapping.Preference, renderZoneDestination(mapping.RecordDestination))
+			case "TXT", "SPF":
+				rdata = normalizeDnsRecordDestination(record.Type, mapping.RecordDestination)
+			default:
+				rdata = mapping.RecordDestination
+			}
+			builder.WriteString(fmt.Sprintf("%s\t%d\tIN\t%s\t%s\n", name, record.Ttl, record.Type, rdata))
+		}
+	}
+	return builder.String()
+}
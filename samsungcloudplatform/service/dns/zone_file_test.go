@@ -0,0 +1,128 @@
+package dns
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testZoneFile = `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1.example.com. admin.example.com. (
+		2024010101 ; serial
+		7200       ; refresh
+		3600 1209600 300 )
+@	IN	NS	ns1.example.com.
+@	300	IN	A	10.0.0.1
+	300	IN	A	10.0.0.2
+www	IN	CNAME	@
+mail	600	IN	MX	20 backup
+mail	IN	600	MX	10 mx.example.net.
+txt	TXT	"v=spf1 include:example.net \"quoted\"" " -all" ; comment
+@	TXT	"v=spf1 -all"
+@	TXT	"verification=abc"
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, err := parseZoneFile(testZoneFile, "Example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []zoneRecord{
+		{Name: "@", Type: "A", Ttl: 300, Mappings: []zoneRecordMapping{{RecordDestination: "10.0.0.1"}, {RecordDestination: "10.0.0.2"}}},
+		{Name: "www", Type: "CNAME", Ttl: 3600, Mappings: []zoneRecordMapping{{RecordDestination: "example.com"}}},
+		{Name: "mail", Type: "MX", Ttl: 600, Mappings: []zoneRecordMapping{
+			{RecordDestination: "backup.example.com", Preference: 20},
+			{RecordDestination: "mx.example.net", Preference: 10},
+		}},
+		{Name: "txt", Type: "TXT", Ttl: 3600, Mappings: []zoneRecordMapping{{RecordDestination: `"v=spf1 include:example.net \"quoted\"" " -all"`}}},
+		{Name: "@", Type: "TXT", Ttl: 3600, Mappings: []zoneRecordMapping{{RecordDestination: `"v=spf1 -all"`}, {RecordDestination: `"verification=abc"`}}},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("unexpected records : %+v", records)
+	}
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	cases := map[string]string{
+		"no ttl":           "@ IN A 10.0.0.1\n",
+		"out of zone":      "$TTL 300\nwww.example.net. IN A 10.0.0.1\n",
+		"unsupported type": "$TTL 300\n@ IN SRV 0 5 5060 sip\n",
+		"invalid address":  "$TTL 300\n@ IN A 2001:db8::1\n",
+		"ttl mismatch":     "@ 300 IN A 10.0.0.1\n@ 600 IN A 10.0.0.2\n",
+		"multiple cname":   "$TTL 300\nwww CNAME a\nwww CNAME b\n",
+		"unterminated":     "$TTL 300\ntxt TXT \"abc\n",
+		"include":          "$INCLUDE other.zone\n",
+	}
+	for name, content := range cases {
+		if _, err := parseZoneFile(content, "example.com"); err == nil {
+			t.Errorf("%s : error expected", name)
+		}
+	}
+}
+
+func TestRenderZoneFileRoundTrip(t *testing.T) {
+	records, err := parseZoneFile(testZoneFile, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	records = append(records, zoneRecord{Name: "long", Type: "TXT", Ttl: 300, Mappings: []zoneRecordMapping{{RecordDestination: strings.Repeat("a", 300)}}})
+
+	rendered := renderZoneFile("example.com", records)
+	parsed, err := parseZoneFile(rendered, "example.com")
+	if err != nil {
+		t.Fatalf("%v\n%s", err, rendered)
+	}
+
+	toMap := func(records []zoneRecord) map[string]zoneRecord {
+		result := make(map[string]zoneRecord)
+		for _, record := range records {
+			result[record.key()] = record
+		}
+		return result
+	}
+	original, roundTrip := toMap(records), toMap(parsed)
+	if len(original) != len(roundTrip) {
+		t.Fatalf("record count mismatch\n%s", rendered)
+	}
+	for key, record := range original {
+		if other := roundTrip[key]; record.signature() != other.signature() {
+			t.Errorf("%s : %+v != %+v", key, record, other)
+		}
+	}
+	if !strings.HasPrefix(rendered, "$ORIGIN example.com.\n@\t300\tIN\tA\t10.0.0.1\n") {
+		t.Errorf("unexpected rendering\n%s", rendered)
+	}
+}
+
+func TestQuoteZoneText(t *testing.T) {
+	tests := []struct {
+		name     string
+		texts    []string
+		expected string
+	}{
+		{"empty", []string{""}, `""`},
+		{"escape", []string{`a "b" \c`}, `"a \"b\" \\c"`},
+		{"strings", []string{"first", "second"}, `"first" "second"`},
+		{"long", []string{strings.Repeat("a", 300)}, `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `"`},
+		// the 255th byte is the first byte of a 2-byte character
+		{"multi-byte", []string{strings.Repeat("a", 254) + "é"}, `"` + strings.Repeat("a", 254) + `" "é"`},
+		{"control", []string{"a\tb\xff"}, `"a\009b\255"`},
+	}
+	for _, test := range tests {
+		if quoted := quoteZoneText(test.texts); quoted != test.expected {
+			t.Errorf("%s : expected %s, got %s", test.name, test.expected, quoted)
+		}
+	}
+}
+
+func TestParseZoneFileTextEscape(t *testing.T) {
+	records, err := parseZoneFile("$TTL 300\ntxt TXT \"caf\\195\\169\"\n", "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if destination := records[0].Mappings[0].RecordDestination; destination != `"café"` {
+		t.Errorf("unexpected destination %s", destination)
+	}
+}
@@ -0,0 +1,540 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/firewall2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_firewall_policy", ResourceFirewallPolicy())
+}
+
+func ResourceFirewallPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFirewallPolicyCreate,
+		ReadContext:   resourceFirewallPolicyRead,
+		UpdateContext: resourceFirewallPolicyUpdate,
+		DeleteContext: resourceFirewallPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceFirewallPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"firewall_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Firewall id",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Ordered list of the firewall rules managed by this resource. Managed rules not in the list are deleted, and the other rules of the firewall are kept.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Rule id",
+						},
+						"direction": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Rule direction. (IN, OUT, IN_OUT)",
+							ValidateFunc: validation.StringInSlice([]string{
+								"IN",
+								"OUT",
+								"IN_OUT",
+							}, false),
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Rule action. (ALLOW, DROP)",
+							ValidateFunc: validation.StringInSlice([]string{
+								"ALLOW",
+								"DROP",
+							}, false),
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Rule enabled state.",
+						},
+						"source_addresses_ipv4": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Description: "Source ip addresses list",
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								DiffSuppressFunc: suppressSubnetMaskDiff,
+							},
+						},
+						"destination_addresses_ipv4": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Description: "Destination ip addresses list",
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								DiffSuppressFunc: suppressSubnetMaskDiff,
+							},
+						},
+						"service": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Description: "Firewall Rule service",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Protocol type. (TCP, UDP, ICMP, ALL)",
+										ValidateFunc: validation.StringInSlice([]string{
+											"TCP",
+											"UDP",
+											"ICMP",
+											"ALL",
+											"TCP_ALL",
+											"UDP_ALL",
+											"ICMP_ALL",
+										}, false),
+									},
+									"value": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Port value",
+									},
+								},
+							},
+						},
+						"description": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Rule description. (0 to 100 characters)",
+							ValidateFunc: validation.StringLenBetween(0, 100),
+						},
+					},
+				},
+			},
+		},
+		Description: "Provides a Firewall Policy resource which manages an ordered list of firewall rules.",
+	}
+}
+
+func suppressSubnetMaskDiff(k, old, new string, d *schema.ResourceData) bool {
+	if !strings.Contains(new, "/") {
+		new = new + "/32"
+	}
+	return old == new
+}
+
+type policyRule struct {
+	Id                   string
+	Direction            string
+	Action               string
+	Enabled              bool
+	SourceAddresses      []string
+	DestinationAddresses []string
+	Services             []firewall2.ServiceVo
+	Description          string
+}
+
+// trafficKey identifies the traffic matched by the rule
+func (r policyRule) trafficKey() string {
+	var services []string
+	for _, s := range r.Services {
+		services = append(services, strings.ToUpper(s.ServiceType)+":"+s.ServiceValue)
+	}
+	sort.Strings(services)
+	return strings.Join([]string{
+		strings.ToUpper(r.Direction),
+		strings.ToUpper(r.Action),
+		strings.Join(common.NormalizeCidrList(r.SourceAddresses), ","),
+		strings.Join(common.NormalizeCidrList(r.DestinationAddresses), ","),
+		strings.Join(services, ","),
+	}, "|")
+}
+
+func (r policyRule) fullKey() string {
+	return fmt.Sprintf("%s|%t|%s", r.trafficKey(), r.Enabled, r.Description)
+}
+
+func expandPolicyRules(rd *schema.ResourceData) []policyRule {
+	var rules []policyRule
+	for _, item := range rd.Get("rule").([]interface{}) {
+		itemObject := item.(map[string]interface{})
+		rule := policyRule{
+			Id:                   itemObject["id"].(string),
+			Direction:            itemObject["direction"].(string),
+			Action:               itemObject["action"].(string),
+			Enabled:              itemObject["enabled"].(bool),
+			SourceAddresses:      common.ToStringList(itemObject["source_addresses_ipv4"].([]interface{})),
+			DestinationAddresses: common.ToStringList(itemObject["destination_addresses_ipv4"].([]interface{})),
+			Description:          itemObject["description"].(string),
+		}
+		for _, valueService := range itemObject["service"].(*schema.Set).List() {
+			s := valueService.(map[string]interface{})
+			rule.Services = append(rule.Services, firewall2.ServiceVo{
+				ServiceType:  strings.ToUpper(s["type"].(string)),
+				ServiceValue: s["value"].(string),
+			})
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// getPolicyRuleIds returns the ids of the rules in the rule list, which are the rules managed by the policy
+func getPolicyRuleIds(ruleList []interface{}) map[string]bool {
+	ruleIds := make(map[string]bool)
+	for _, item := range ruleList {
+		if ruleId, ok := item.(map[string]interface{})["id"].(string); ok && len(ruleId) != 0 {
+			ruleIds[ruleId] = true
+		}
+	}
+	return ruleIds
+}
+
+// filterPolicyRules returns the rules with the ids in the firewall order
+func filterPolicyRules(rules []policyRule, ruleIds map[string]bool) []policyRule {
+	var result []policyRule
+	for _, rule := range rules {
+		if ruleIds[rule.Id] {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+func listPolicyRules(ctx context.Context, inst *client.Instance, firewallId string) ([]policyRule, error) {
+	rules, _, err := inst.Client.Firewall.ListFirewallRules(ctx, firewallId)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []policyRule
+	for _, rule := range rules.Contents {
+		if rule.RuleState == common.DeletedState || rule.RuleState == common.TerminatingState {
+			continue
+		}
+		r := policyRule{
+			Id:                   rule.RuleId,
+			Direction:            rule.RuleDirection,
+			Action:               rule.RuleAction,
+			Enabled:              rule.IsRuleEnabled != nil && *rule.IsRuleEnabled,
+			SourceAddresses:      addSubnetMask(rule.SourceIpAddresses),
+			DestinationAddresses: addSubnetMask(rule.DestinationIpAddresses),
+			Description:          rule.RuleDescription,
+		}
+		if rule.IsAllService != nil && *rule.IsAllService {
+			r.Services = append(r.Services, firewall2.ServiceVo{ServiceType: "ALL"})
+		} else {
+			for _, svc := range rule.TcpServices {
+				r.Services = append(r.Services, firewall2.ServiceVo{ServiceType: "TCP", ServiceValue: svc})
+			}
+			for _, svc := range rule.UdpServices {
+				r.Services = append(r.Services, firewall2.ServiceVo{ServiceType: "UDP", ServiceValue: svc})
+			}
+			for _, svc := range rule.IcmpServices {
+				r.Services = append(r.Services, firewall2.ServiceVo{ServiceType: "ICMP", ServiceValue: svc})
+			}
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// matchPolicyRules pairs each desired rule with a current rule.
+// Exactly matched rules are preferred, then rules matching the same traffic, then the remaining rules in order.
+// Returns current rule index for each desired rule (-1 if a new rule is required) and unmatched current rules.
+func matchPolicyRules(current []policyRule, desired []policyRule) ([]int, []policyRule) {
+	matched := make([]int, len(desired))
+	for i := range matched {
+		matched[i] = -1
+	}
+	used := make([]bool, len(current))
+
+	keyFuncs := []func(policyRule) string{
+		policyRule.fullKey,
+		policyRule.trafficKey,
+	}
+	for _, keyFunc := range keyFuncs {
+		for i, d := range desired {
+			if matched[i] >= 0 {
+				continue
+			}
+			for j, c := range current {
+				if !used[j] && keyFunc(c) == keyFunc(d) {
+					matched[i] = j
+					used[j] = true
+					break
+				}
+			}
+		}
+	}
+
+	// Remaining rules are modified in place rather than deleted and recreated
+	j := 0
+	for i := range desired {
+		if matched[i] >= 0 {
+			continue
+		}
+		for j < len(current) && used[j] {
+			j++
+		}
+		if j < len(current) {
+			matched[i] = j
+			used[j] = true
+		}
+	}
+
+	var unmatched []policyRule
+	for j, c := range current {
+		if !used[j] {
+			unmatched = append(unmatched, c)
+		}
+	}
+	return matched, unmatched
+}
+
+// convergeFirewallPolicy changes the rules managed by the policy into the desired rules, and returns the ids of the desired rules.
+// The other rules of the firewall are neither changed nor moved.
+func convergeFirewallPolicy(ctx context.Context, inst *client.Instance, firewallId string, managedIds map[string]bool, desired []policyRule) ([]string, error) {
+	allRules, err := listPolicyRules(ctx, inst, firewallId)
+	if err != nil {
+		return nil, err
+	}
+	current := filterPolicyRules(allRules, managedIds)
+
+	matched, unmatched := matchPolicyRules(current, desired)
+
+	// 1. Delete rules not in the policy
+	for _, rule := range unmatched {
+		_, _, err := inst.Client.Firewall.DeleteFirewallRule(ctx, firewallId, rule.Id)
+		if err != nil && !common.IsDeleted(err) {
+			return nil, err
+		}
+		err = waitForFirewallRuleStatus(ctx, inst.Client, firewallId, rule.Id, []string{common.TerminatingState}, []string{common.DeletedState}, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. Update matched rules and create new rules at the last position
+	desiredIds := make([]string, len(desired))
+	for i, d := range desired {
+		if matched[i] < 0 {
+			response, _, err := inst.Client.Firewall.CreateFirewallRule(ctx, firewallId, firewall2.FirewallCreateRuleRequest{
+				SourceIpAddresses:      d.SourceAddresses,
+				DestinationIpAddresses: d.DestinationAddresses,
+				Services:               d.Services,
+				RuleDirection:          d.Direction,
+				RuleAction:             d.Action,
+				IsRuleEnabled:          &d.Enabled,
+				RuleLocationType:       "LAST",
+				RuleLocationId:         "",
+				RuleDescription:        d.Description,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = waitForFirewallRuleStatus(ctx, inst.Client, firewallId, response.ResourceId, []string{common.CreatingState}, []string{common.ActiveState}, true)
+			if err != nil {
+				return nil, err
+			}
+			desiredIds[i] = response.ResourceId
+			continue
+		}
+
+		c := current[matched[i]]
+		desiredIds[i] = c.Id
+		if c.trafficKey() != d.trafficKey() || c.Description != d.Description {
+			_, _, err := inst.Client.Firewall.UpdateFirewallRule(ctx, firewallId, c.Id, firewall2.FirewallRuleUpdateRequest{
+				SourceIpAddresses:      d.SourceAddresses,
+				DestinationIpAddresses: d.DestinationAddresses,
+				Services:               d.Services,
+				RuleDirection:          d.Direction,
+				RuleAction:             d.Action,
+				RuleDescription:        d.Description,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = waitForFirewallRuleStatus(ctx, inst.Client, firewallId, c.Id, []string{common.DeployingState}, []string{common.ActiveState}, true)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if c.Enabled != d.Enabled {
+			_, _, err := inst.Client.Firewall.UpdateFirewallRuleEnable(ctx, firewallId, c.Id, d.Enabled)
+			if err != nil {
+				return nil, err
+			}
+			err = waitForFirewallRuleStatus(ctx, inst.Client, firewallId, c.Id, []string{common.DeployingState}, []string{common.ActiveState}, true)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 3. Reorder the rules of the policy with minimal moves
+	allRules, err = listPolicyRules(ctx, inst, firewallId)
+	if err != nil {
+		return nil, err
+	}
+	desiredIdSet := make(map[string]bool)
+	for _, ruleId := range desiredIds {
+		desiredIdSet[ruleId] = true
+	}
+	var currentIds []string
+	for _, c := range filterPolicyRules(allRules, desiredIdSet) {
+		currentIds = append(currentIds, c.Id)
+	}
+	for _, move := range planRuleMoves(currentIds, desiredIds) {
+		_, _, err := inst.Client.Firewall.UpdateFirewallRuleLocation(ctx, firewallId, move.RuleId, firewall2.FirewallRuleChangeLocationRequest{
+			LocationRuleId:   move.LocationRuleId,
+			RuleLocationType: move.RuleLocationType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = waitForFirewallRuleStatus(ctx, inst.Client, firewallId, move.RuleId, []string{common.DeployingState}, []string{common.ActiveState}, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return desiredIds, nil
+}
+
+// setPolicyRuleIds sets the ids of the rules created or matched by the policy, so that only those rules are read
+func setPolicyRuleIds(rd *schema.ResourceData, ruleIds []string) {
+	ruleList := rd.Get("rule").([]interface{})
+	for i, item := range ruleList {
+		if i < len(ruleIds) {
+			item.(map[string]interface{})["id"] = ruleIds[i]
+		}
+	}
+	rd.Set("rule", ruleList)
+}
+
+func resourceFirewallPolicyCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	firewallId := rd.Get("firewall_id").(string)
+	firewallMutexKV.Lock(firewallId)
+	defer firewallMutexKV.Unlock(firewallId)
+
+	inst := meta.(*client.Instance)
+
+	// the rules already in the firewall are not managed by the new policy
+	ruleIds, err := convergeFirewallPolicy(ctx, inst, firewallId, map[string]bool{}, expandPolicyRules(rd))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rd.SetId(firewallId)
+	setPolicyRuleIds(rd, ruleIds)
+
+	return resourceFirewallPolicyRead(ctx, rd, meta)
+}
+
+func resourceFirewallPolicyRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	allRules, err := listPolicyRules(ctx, inst, rd.Id())
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	rules := filterPolicyRules(allRules, getPolicyRuleIds(rd.Get("rule").([]interface{})))
+
+	ruleList := common.HclListObject{}
+	for _, rule := range rules {
+		s := common.HclSetObject{}
+		for _, svc := range rule.Services {
+			s = append(s, common.HclKeyValueObject{
+				"type":  svc.ServiceType,
+				"value": svc.ServiceValue,
+			})
+		}
+		ruleList = append(ruleList, common.HclKeyValueObject{
+			"id":                         rule.Id,
+			"direction":                  rule.Direction,
+			"action":                     rule.Action,
+			"enabled":                    rule.Enabled,
+			"source_addresses_ipv4":      rule.SourceAddresses,
+			"destination_addresses_ipv4": rule.DestinationAddresses,
+			"service":                    s,
+			"description":                rule.Description,
+		})
+	}
+
+	rd.Set("firewall_id", rd.Id())
+	rd.Set("rule", ruleList)
+
+	return nil
+}
+
+func resourceFirewallPolicyUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	firewallId := rd.Get("firewall_id").(string)
+	firewallMutexKV.Lock(firewallId)
+	defer firewallMutexKV.Unlock(firewallId)
+
+	inst := meta.(*client.Instance)
+
+	if rd.HasChanges("rule") {
+		oldRules, _ := rd.GetChange("rule")
+		ruleIds, err := convergeFirewallPolicy(ctx, inst, rd.Id(), getPolicyRuleIds(oldRules.([]interface{})), expandPolicyRules(rd))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		setPolicyRuleIds(rd, ruleIds)
+	}
+
+	return resourceFirewallPolicyRead(ctx, rd, meta)
+}
+
+func resourceFirewallPolicyDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	firewallId := rd.Get("firewall_id").(string)
+	firewallMutexKV.Lock(firewallId)
+	defer firewallMutexKV.Unlock(firewallId)
+
+	inst := meta.(*client.Instance)
+
+	_, err := convergeFirewallPolicy(ctx, inst, rd.Id(), getPolicyRuleIds(rd.Get("rule").([]interface{})), nil)
+	if err != nil && !common.IsDeleted(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceFirewallPolicyImport manages every rule of the firewall at the time of the import
+func resourceFirewallPolicyImport(ctx context.Context, rd *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	inst := meta.(*client.Instance)
+
+	rules, err := listPolicyRules(ctx, inst, rd.Id())
+	if err != nil {
+		return nil, err
+	}
+	ruleList := common.HclListObject{}
+	for _, rule := range rules {
+		ruleList = append(ruleList, common.HclKeyValueObject{"id": rule.Id})
+	}
+
+	rd.Set("firewall_id", rd.Id())
+	rd.Set("rule", ruleList)
+	return []*schema.ResourceData{rd}, nil
+}
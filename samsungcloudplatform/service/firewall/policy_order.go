@@ -0,0 +1,85 @@
+package firewall
+
+// ruleMove is a single UpdateFirewallRuleLocation call
+type ruleMove struct {
+	RuleId           string
+	RuleLocationType string
+	LocationRuleId   string
+}
+
+// longestIncreasingSubsequence returns indexes of values which make the longest increasing subsequence
+func longestIncreasingSubsequence(values []int) []int {
+	if len(values) == 0 {
+		return nil
+	}
+
+	// tails[k] is the index of the smallest tail value of increasing subsequences with length k+1
+	tails := make([]int, 0, len(values))
+	prev := make([]int, len(values))
+	for i, v := range values {
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if values[tails[mid]] < v {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		if lo > 0 {
+			prev[i] = tails[lo-1]
+		} else {
+			prev[i] = -1
+		}
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+
+	result := make([]int, len(tails))
+	for i, k := len(tails)-1, tails[len(tails)-1]; i >= 0; i, k = i-1, prev[k] {
+		result[i] = k
+	}
+	return result
+}
+
+// planRuleMoves returns the minimal moves to reorder current rule ids into desired rule ids.
+// Both lists must contain the same rule ids.
+// Rules in the longest subsequence already in the desired order stay, and the others are moved
+// after the preceding rule of the desired order. (or before the first rule which stays)
+// The moves are relative to the listed rules, so the other rules of the firewall keep their positions.
+func planRuleMoves(current []string, desired []string) []ruleMove {
+	position := make(map[string]int)
+	for i, ruleId := range current {
+		position[ruleId] = i
+	}
+
+	positions := make([]int, len(desired))
+	for i, ruleId := range desired {
+		positions[i] = position[ruleId]
+	}
+
+	stay := make(map[int]bool)
+	firstStay := -1
+	for _, i := range longestIncreasingSubsequence(positions) {
+		stay[i] = true
+		if firstStay < 0 || i < firstStay {
+			firstStay = i
+		}
+	}
+
+	var moves []ruleMove
+	for i, ruleId := range desired {
+		if stay[i] {
+			continue
+		}
+		if i == 0 {
+			moves = append(moves, ruleMove{RuleId: ruleId, RuleLocationType: "BEFORE", LocationRuleId: desired[firstStay]})
+		} else {
+			moves = append(moves, ruleMove{RuleId: ruleId, RuleLocationType: "AFTER", LocationRuleId: desired[i-1]})
+		}
+	}
+	return moves
+}
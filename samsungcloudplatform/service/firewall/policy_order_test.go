@@ -0,0 +1,68 @@
+package firewall
+
+import (
+	"reflect"
+	"testing"
+)
+
+func applyRuleMoves(current []string, moves []ruleMove) []string {
+	result := append([]string{}, current...)
+	for _, move := range moves {
+		for i, ruleId := range result {
+			if ruleId == move.RuleId {
+				result = append(result[:i], result[i+1:]...)
+				break
+			}
+		}
+		index := 0
+		if move.RuleLocationType == "AFTER" || move.RuleLocationType == "BEFORE" {
+			for i, ruleId := range result {
+				if ruleId == move.LocationRuleId {
+					index = i
+					if move.RuleLocationType == "AFTER" {
+						index++
+					}
+					break
+				}
+			}
+		}
+		result = append(result[:index], append([]string{move.RuleId}, result[index:]...)...)
+	}
+	return result
+}
+
+func TestPlanRuleMoves(t *testing.T) {
+	cases := []struct {
+		current   []string
+		desired   []string
+		moveCount int
+	}{
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}, 0},
+		{[]string{"a", "b", "c", "d"}, []string{"d", "a", "b", "c"}, 1},
+		{[]string{"a", "b", "c", "d"}, []string{"a", "c", "b", "d"}, 1},
+		{[]string{"a", "b", "c", "d"}, []string{"d", "c", "b", "a"}, 3},
+		{[]string{}, []string{}, 0},
+	}
+
+	for _, c := range cases {
+		moves := planRuleMoves(c.current, c.desired)
+		if len(moves) != c.moveCount {
+			t.Errorf("%v -> %v : expected %d moves but %d", c.current, c.desired, c.moveCount, len(moves))
+		}
+		if result := applyRuleMoves(c.current, moves); len(c.desired) != 0 && !reflect.DeepEqual(result, c.desired) {
+			t.Errorf("%v -> %v : moved result is %v", c.current, c.desired, result)
+		}
+	}
+}
+
+func TestPlanRuleMovesKeepsOtherRules(t *testing.T) {
+	// x and y are not managed by the policy, so they are not listed in the moves
+	all := []string{"x", "a", "b", "y", "c"}
+	moves := planRuleMoves([]string{"a", "b", "c"}, []string{"c", "a", "b"})
+	if len(moves) != 1 {
+		t.Fatalf("expected 1 move but %v", moves)
+	}
+	if result := applyRuleMoves(all, moves); !reflect.DeepEqual(result, []string{"x", "c", "a", "b", "y"}) {
+		t.Errorf("moved result is %v", result)
+	}
+}
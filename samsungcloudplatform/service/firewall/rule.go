@@ -11,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"strings"
-	"sync"
 )
 
 func init() {
@@ -150,8 +149,10 @@ func ResourceFirewallRule() *schema.Resource {
 					"LAST",
 				}, false),
 			},
+			"overlap_check": overlapCheckSchema(),
 		},
-		Description: "Provides a Firewall Rule resource.",
+		CustomizeDiff: resourceFirewallRuleDiff,
+		Description:   "Provides a Firewall Rule resource.",
 	}
 }
 
@@ -159,6 +160,7 @@ func ResourceFirewallBulkRule() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceFirewallBulkRuleCreate,
 		ReadContext:   resourceFirewallBulkRuleRead,
+		UpdateContext: schema.NoopContext,
 		DeleteContext: resourceFirewallBulkRuleDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -272,8 +274,18 @@ func ResourceFirewallBulkRule() *schema.Resource {
 					},
 				},
 			},
+			"rule_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Ids of the rules created by this resource",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"overlap_check": overlapCheckSchema(),
 		},
-		Description: "Provides a Firewall Rule resource.",
+		CustomizeDiff: resourceFirewallBulkRuleDiff,
+		Description:   "Provides a Firewall Rule resource.",
 	}
 }
 
@@ -396,14 +408,14 @@ func addSubnetMask(ipAddress []string) []string {
 	return ipList
 }
 
-var mutex sync.Mutex
+// firewallMutexKV serializes the changes of the rules of a firewall, which are made by the rule, bulk rule and policy resources
+var firewallMutexKV = common.NewMutexKV()
 
 func resourceFirewallRuleCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// TODO Implement locking for each firewall_id
-	mutex.Lock()
-	defer mutex.Unlock()
-
 	firewallId := rd.Get("firewall_id").(string)
+	firewallMutexKV.Lock(firewallId)
+	defer firewallMutexKV.Unlock(firewallId)
+
 	action := rd.Get("action").(string)
 	direction := rd.Get("direction").(string)
 
@@ -510,11 +522,10 @@ func resourceFirewallRuleRead(ctx context.Context, rd *schema.ResourceData, meta
 }
 
 func resourceFirewallRuleUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// TODO Implement locking for each firewall_id
-	mutex.Lock()
-	defer mutex.Unlock()
-
 	firewallId := rd.Get("firewall_id").(string)
+	firewallMutexKV.Lock(firewallId)
+	defer firewallMutexKV.Unlock(firewallId)
+
 	inst := meta.(*client.Instance)
 
 	isEnabled := rd.Get("enabled").(bool)
@@ -586,14 +597,12 @@ func resourceFirewallRuleUpdate(ctx context.Context, rd *schema.ResourceData, me
 }
 
 func resourceFirewallRuleDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// TODO Implement locking for each firewall_id
-	mutex.Lock()
-	defer mutex.Unlock()
+	firewallId := rd.Get("firewall_id").(string)
+	firewallMutexKV.Lock(firewallId)
+	defer firewallMutexKV.Unlock(firewallId)
 
 	inst := meta.(*client.Instance)
 
-	firewallId := rd.Get("firewall_id").(string)
-
 	_, _, err := inst.Client.Firewall.DeleteFirewallRule(ctx, firewallId, rd.Id())
 	if err != nil && !common.IsDeleted(err) {
 		return diag.FromErr(err)
@@ -608,11 +617,9 @@ func resourceFirewallRuleDelete(ctx context.Context, rd *schema.ResourceData, me
 }
 
 func resourceFirewallBulkRuleCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// TODO Implement locking for each firewall_id
-	mutex.Lock()
-	defer mutex.Unlock()
-
 	firewallId := rd.Get("firewall_id").(string)
+	firewallMutexKV.Lock(firewallId)
+	defer firewallMutexKV.Unlock(firewallId)
 
 	bulkRuleLocationType := rd.Get("bulk_rule_location_type").(string)
 	bulkRuleLocationId := rd.Get("bulk_rule_location_id").(string)
@@ -624,6 +631,11 @@ func resourceFirewallBulkRuleCreate(ctx context.Context, rd *schema.ResourceData
 
 	inst := meta.(*client.Instance)
 
+	previousEntries, err := listFirewallRuleEntries(ctx, inst, firewallId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	response, _, err := inst.Client.Firewall.CreateFirewallBulkRule(ctx, firewallId, firewall2.FirewallRuleCreateBulkRequest{
 		BulkRuleLocationType: bulkRuleLocationType,
 		BulkRuleLocationId:   bulkRuleLocationId,
@@ -642,6 +654,21 @@ func resourceFirewallBulkRuleCreate(ctx context.Context, rd *schema.ResourceData
 
 	rd.SetId(response.ResourceId)
 
+	// Rules which did not exist before the request are the ones created by this resource
+	currentEntries, err := listFirewallRuleEntries(ctx, inst, firewallId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	previousIds := make(map[string]bool)
+	for _, entry := range previousEntries {
+		previousIds[entry.Id] = true
+	}
+	var ruleIds []string
+	for _, entry := range excludeRuleEntries(currentEntries, previousIds) {
+		ruleIds = append(ruleIds, entry.Id)
+	}
+	rd.Set("rule_ids", ruleIds)
+
 	return nil
 }
 
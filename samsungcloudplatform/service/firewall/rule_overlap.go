@@ -0,0 +1,257 @@
+package firewall
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func overlapCheckSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     common.OverlapCheckNone,
+		Description: "Plan time rule overlap check mode. (ERROR, WARN, NONE) Defaults to NONE. Duplicated rules are rejected unless NONE. Rules shadowed by preceding rules are rejected in ERROR mode and written to the provider log as warning in WARN mode, which is shown with TF_LOG=WARN.",
+		ValidateFunc: validation.StringInSlice([]string{
+			common.OverlapCheckError,
+			common.OverlapCheckWarn,
+			common.OverlapCheckNone,
+		}, false),
+	}
+}
+
+func toNetworkRule(id string, direction string, action string, sources []string, destinations []string, services []common.RuleService) common.NetworkRule {
+	return common.NetworkRule{
+		Id:                   id,
+		Direction:            strings.ToUpper(direction),
+		Action:               strings.ToUpper(action),
+		SourceAddresses:      common.NormalizeCidrList(addSubnetMask(sources)),
+		DestinationAddresses: common.NormalizeCidrList(addSubnetMask(destinations)),
+		Services:             services,
+	}
+}
+
+func expandRuleServices(servicesSet *schema.Set) []common.RuleService {
+	var services []common.RuleService
+	for _, valueService := range servicesSet.List() {
+		s := valueService.(map[string]interface{})
+		services = append(services, common.RuleService{
+			Type:  s["type"].(string),
+			Value: s["value"].(string),
+		})
+	}
+	return services
+}
+
+// firewallRuleEntry is a rule of the firewall in evaluation order, converted for the overlap check.
+type firewallRuleEntry struct {
+	Id      string
+	Enabled bool
+	Rule    common.NetworkRule
+}
+
+// listFirewallRuleEntries returns the rules of the firewall in evaluation order. Deleted rules are excluded.
+func listFirewallRuleEntries(ctx context.Context, inst *client.Instance, firewallId string) ([]firewallRuleEntry, error) {
+	rules, _, err := inst.Client.Firewall.ListFirewallRules(ctx, firewallId)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []firewallRuleEntry
+	for _, rule := range rules.Contents {
+		if rule.RuleState == common.DeletedState || rule.RuleState == common.TerminatingState {
+			continue
+		}
+
+		var services []common.RuleService
+		if rule.IsAllService != nil && *rule.IsAllService {
+			services = append(services, common.RuleService{Type: "ALL"})
+		}
+		for _, svc := range rule.TcpServices {
+			services = append(services, common.RuleService{Type: "TCP", Value: svc})
+		}
+		for _, svc := range rule.UdpServices {
+			services = append(services, common.RuleService{Type: "UDP", Value: svc})
+		}
+		for _, svc := range rule.IcmpServices {
+			services = append(services, common.RuleService{Type: "ICMP", Value: svc})
+		}
+		result = append(result, firewallRuleEntry{
+			Id:      rule.RuleId,
+			Enabled: rule.IsRuleEnabled != nil && *rule.IsRuleEnabled,
+			Rule:    toNetworkRule(rule.RuleId, rule.RuleDirection, rule.RuleAction, rule.SourceIpAddresses, rule.DestinationIpAddresses, services),
+		})
+	}
+	return result, nil
+}
+
+// excludeRuleEntries removes the rules managed by the resource itself from the entries
+func excludeRuleEntries(entries []firewallRuleEntry, excludeIds map[string]bool) []firewallRuleEntry {
+	var result []firewallRuleEntry
+	for _, entry := range entries {
+		if !excludeIds[entry.Id] {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// enabledNetworkRules returns the rules of the enabled entries, which are the only ones evaluated by the firewall
+func enabledNetworkRules(entries []firewallRuleEntry) []common.NetworkRule {
+	var result []common.NetworkRule
+	for _, entry := range entries {
+		if entry.Enabled {
+			result = append(result, entry.Rule)
+		}
+	}
+	return result
+}
+
+// rulePlacementIndex returns the number of entries located before a rule placed with the location type.
+// False is returned if the placement cannot be worked out, e.g. the location rule is unknown.
+func rulePlacementIndex(entries []firewallRuleEntry, locationType string, locationRuleId string) (int, bool) {
+	switch locationType {
+	case "", "LAST":
+		return len(entries), true
+	case "FIRST":
+		return 0, true
+	case "BEFORE", "AFTER":
+		for i, entry := range entries {
+			if entry.Id == locationRuleId {
+				if locationType == "AFTER" {
+					return i + 1, true
+				}
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// checkRuleEntryOverlaps checks the new rules against the existing entries.
+// Duplicates are checked against all enabled rules, shadowing only against the rules located before the new rules.
+func checkRuleEntryOverlaps(newRules []common.NetworkRule, entries []firewallRuleEntry, placementIndex int, placementKnown bool) ([]string, []string) {
+	duplicates, _ := common.CheckRuleOverlaps(newRules, enabledNetworkRules(entries))
+	if !placementKnown {
+		// Rules among the new rules are still checked for shadowing
+		_, shadowed := common.CheckRuleOverlaps(newRules, nil)
+		return duplicates, shadowed
+	}
+	_, shadowed := common.CheckRuleOverlaps(newRules, enabledNetworkRules(entries[:placementIndex]))
+	return duplicates, shadowed
+}
+
+func resourceFirewallRuleDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	mode := diff.Get("overlap_check").(string)
+	if mode == common.OverlapCheckNone {
+		return nil
+	}
+	if len(diff.Id()) != 0 && !diff.HasChanges("action", "direction", "source_addresses_ipv4", "destination_addresses_ipv4", "service", "overlap_check", "rule_location_type", "location_rule_id") {
+		return nil
+	}
+	if !diff.NewValueKnown("firewall_id") || !diff.NewValueKnown("source_addresses_ipv4") || !diff.NewValueKnown("destination_addresses_ipv4") || !diff.NewValueKnown("service") {
+		return nil
+	}
+
+	newRule := toNetworkRule(diff.Id(),
+		diff.Get("direction").(string),
+		diff.Get("action").(string),
+		common.ToStringList(diff.Get("source_addresses_ipv4").([]interface{})),
+		common.ToStringList(diff.Get("destination_addresses_ipv4").([]interface{})),
+		expandRuleServices(diff.Get("service").(*schema.Set)))
+
+	inst := meta.(*client.Instance)
+	allEntries, err := listFirewallRuleEntries(ctx, inst, diff.Get("firewall_id").(string))
+	if err != nil {
+		return err
+	}
+	entries := excludeRuleEntries(allEntries, map[string]bool{diff.Id(): true})
+
+	var placementIndex int
+	var placementKnown bool
+	if len(diff.Id()) == 0 || diff.HasChange("firewall_id") {
+		// New rules are created at the last location
+		placementIndex, placementKnown = rulePlacementIndex(entries, "LAST", "")
+	} else if !diff.HasChanges("rule_location_type", "location_rule_id") {
+		// Rule stays where it is
+		placementIndex, placementKnown = rulePlacementIndex(allEntries, "BEFORE", diff.Id())
+	} else if diff.NewValueKnown("rule_location_type") && diff.NewValueKnown("location_rule_id") {
+		placementIndex, placementKnown = rulePlacementIndex(entries, diff.Get("rule_location_type").(string), diff.Get("location_rule_id").(string))
+	}
+
+	duplicates, shadowed := checkRuleEntryOverlaps([]common.NetworkRule{newRule}, entries, placementIndex, placementKnown)
+	return common.RuleOverlapError(mode, duplicates, shadowed)
+}
+
+// bulkRuleOwnedIds returns the ids of the rules created by the bulk rule resource itself.
+// States created before rule_ids was introduced fall back to matching the rules in prior state.
+func bulkRuleOwnedIds(diff *schema.ResourceDiff, entries []firewallRuleEntry) map[string]bool {
+	owned := make(map[string]bool)
+	if len(diff.Id()) == 0 {
+		return owned
+	}
+	for _, ruleId := range diff.Get("rule_ids").([]interface{}) {
+		owned[ruleId.(string)] = true
+	}
+	if len(owned) != 0 {
+		return owned
+	}
+
+	oldRules, _ := diff.GetChange("rule")
+	for _, rule := range oldRules.(*schema.Set).List() {
+		oldRule := expandBulkNetworkRule(rule.(common.HclKeyValueObject))
+		for _, entry := range entries {
+			if common.RuleEquals(entry.Rule, oldRule) {
+				owned[entry.Id] = true
+			}
+		}
+	}
+	return owned
+}
+
+func expandBulkNetworkRule(itemObject common.HclKeyValueObject) common.NetworkRule {
+	return toNetworkRule("",
+		itemObject["direction"].(string),
+		itemObject["action"].(string),
+		common.ToStringList(itemObject["source_addresses_ipv4"].([]interface{})),
+		common.ToStringList(itemObject["destination_addresses_ipv4"].([]interface{})),
+		expandRuleServices(itemObject["service"].(*schema.Set)))
+}
+
+func resourceFirewallBulkRuleDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	mode := diff.Get("overlap_check").(string)
+	if mode == common.OverlapCheckNone {
+		return nil
+	}
+	if len(diff.Id()) != 0 && !diff.HasChanges("rule", "overlap_check") {
+		return nil
+	}
+	if !diff.NewValueKnown("firewall_id") || !diff.NewValueKnown("rule") {
+		return nil
+	}
+
+	var newRules []common.NetworkRule
+	for _, rule := range diff.Get("rule").(*schema.Set).List() {
+		newRules = append(newRules, expandBulkNetworkRule(rule.(common.HclKeyValueObject)))
+	}
+
+	inst := meta.(*client.Instance)
+	allEntries, err := listFirewallRuleEntries(ctx, inst, diff.Get("firewall_id").(string))
+	if err != nil {
+		return err
+	}
+	entries := excludeRuleEntries(allEntries, bulkRuleOwnedIds(diff, allEntries))
+
+	var placementIndex int
+	var placementKnown bool
+	if diff.NewValueKnown("bulk_rule_location_id") {
+		placementIndex, placementKnown = rulePlacementIndex(entries, diff.Get("bulk_rule_location_type").(string), diff.Get("bulk_rule_location_id").(string))
+	}
+
+	duplicates, shadowed := checkRuleEntryOverlaps(newRules, entries, placementIndex, placementKnown)
+	return common.RuleOverlapError(mode, duplicates, shadowed)
+}
@@ -0,0 +1,56 @@
+package firewall
+
+import (
+	"testing"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+)
+
+func TestRulePlacementIndex(t *testing.T) {
+	entries := []firewallRuleEntry{{Id: "a"}, {Id: "b"}, {Id: "c"}}
+	cases := []struct {
+		locationType   string
+		locationRuleId string
+		index          int
+		known          bool
+	}{
+		{"", "", 3, true},
+		{"LAST", "", 3, true},
+		{"FIRST", "", 0, true},
+		{"BEFORE", "a", 0, true},
+		{"BEFORE", "c", 2, true},
+		{"AFTER", "a", 1, true},
+		{"AFTER", "c", 3, true},
+		{"AFTER", "unknown", 0, false},
+	}
+
+	for _, c := range cases {
+		index, known := rulePlacementIndex(entries, c.locationType, c.locationRuleId)
+		if index != c.index || known != c.known {
+			t.Errorf("%s %s : expected (%d, %t) but (%d, %t)", c.locationType, c.locationRuleId, c.index, c.known, index, known)
+		}
+	}
+}
+
+func TestCheckRuleEntryOverlaps(t *testing.T) {
+	allowAll := toNetworkRule("a", "IN", "ALLOW", []string{"0.0.0.0/0"}, []string{"0.0.0.0/0"}, []common.RuleService{{Type: "ALL"}})
+	disabled := toNetworkRule("b", "IN", "DROP", []string{"10.0.0.1"}, []string{"10.0.1.0/24"}, []common.RuleService{{Type: "TCP", Value: "22"}})
+	entries := []firewallRuleEntry{
+		{Id: "a", Enabled: true, Rule: allowAll},
+		{Id: "b", Enabled: false, Rule: disabled},
+	}
+	newRule := toNetworkRule("", "IN", "ALLOW", []string{"10.0.0.1"}, []string{"10.0.1.0/24"}, []common.RuleService{{Type: "TCP", Value: "22"}})
+
+	if duplicates, shadowed := checkRuleEntryOverlaps([]common.NetworkRule{newRule}, entries, 2, true); len(duplicates) != 0 || len(shadowed) != 1 {
+		t.Errorf("LAST : expected 0 duplicates and 1 shadowed but %v %v", duplicates, shadowed)
+	}
+	if duplicates, shadowed := checkRuleEntryOverlaps([]common.NetworkRule{newRule}, entries, 0, true); len(duplicates) != 0 || len(shadowed) != 0 {
+		t.Errorf("FIRST : expected no overlap but %v %v", duplicates, shadowed)
+	}
+	if duplicates, shadowed := checkRuleEntryOverlaps([]common.NetworkRule{newRule}, entries, 0, false); len(duplicates) != 0 || len(shadowed) != 0 {
+		t.Errorf("unknown placement : expected no overlap but %v %v", duplicates, shadowed)
+	}
+	if duplicates, _ := checkRuleEntryOverlaps([]common.NetworkRule{allowAll}, excludeRuleEntries(entries, map[string]bool{"a": true}), 1, true); len(duplicates) != 0 {
+		t.Errorf("owned rule : expected no duplicates but %v", duplicates)
+	}
+}
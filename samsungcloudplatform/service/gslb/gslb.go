@@ -90,27 +90,71 @@ func ResourceGslb() *schema.Resource {
 			"gslb_health_check_user_id": {
 				Type:             schema.TypeString,
 				Optional:         true,
+				Sensitive:        true,
 				Description:      "GSLB Health Check User Id",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(0, 60)),
 			},
 			"gslb_health_check_user_password": {
 				Type:             schema.TypeString,
 				Optional:         true,
+				Sensitive:        true,
 				Description:      "GSLB Health Check User Password",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(0, 250)),
 			},
 			"gslb_send_string": {
 				Type:             schema.TypeString,
 				Optional:         true,
-				Description:      "GSLB Health Check Send String",
+				Computed:         true,
+				Description:      "GSLB Health Check Send String. Rendered from health_check if it is specified, and cleared if neither of them is specified.",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(0, 300)),
 			},
 			"gslb_response_string": {
 				Type:             schema.TypeString,
 				Optional:         true,
-				Description:      "GSLB Health Check Response String",
+				Computed:         true,
+				Description:      "GSLB Health Check Response String. Rendered from health_check if it is specified, and cleared if neither of them is specified.",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(0, 300)),
 			},
+			"health_check": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Structured HTTP(S) health check, rendered into gslb_send_string and gslb_response_string. Can not be used with gslb_send_string and gslb_response_string.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"method": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "GET",
+							Description: "HTTP method of the probe",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "/",
+							Description: "Request path of the probe",
+						},
+						"headers": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Request headers of the probe. HTTP/1.1 is used if the Host header is specified, otherwise HTTP/1.0.",
+						},
+						"expected_status_codes": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "HTTP status codes of a healthy response. (default [200])",
+						},
+						"expected_body_regex": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Regular expression which the response body of a healthy response must match",
+						},
+					},
+				},
+			},
 			"gslb_resources": {
 				Type:        schema.TypeSet,
 				Required:    true,
@@ -151,7 +195,8 @@ func ResourceGslb() *schema.Resource {
 			},
 			"tags": tfTags.TagsSchema(),
 		},
-		Description: "Provides a Gslb resource.",
+		CustomizeDiff: resourceGslbHealthCheckDiff,
+		Description:   "Provides a Gslb resource.",
 	}
 }
 
@@ -219,7 +264,7 @@ func validateGslbTimeResourceCount(rd *schema.ResourceData) error {
 
 	gslbResourceList := rd.Get("gslb_resources").(*schema.Set).List()
 	if len(gslbResourceList) < 1 {
-		return fmt.Errorf("There must be at least one gslb_resource in GSLB Serivce.")
+		return fmt.Errorf("There must be at least one gslb_resource in GSLB Service.")
 	} else {
 		return nil
 	}
@@ -343,13 +388,29 @@ func resourceGslbRead(ctx context.Context, rd *schema.ResourceData, meta interfa
 	rd.Set("gslb_health_check_user_id", gslbInfo.GslbHealthCheck.GslbHealthCheckUserId)
 	rd.Set("gslb_send_string", gslbInfo.GslbHealthCheck.GslbSendString)
 	rd.Set("gslb_response_string", gslbInfo.GslbHealthCheck.GslbResponseString)
+	if len(rd.Get("health_check").([]interface{})) != 0 {
+		// health_check is cleared if the probe strings were changed to a form which can not be parsed
+		healthCheck, ok := parseHttpHealthCheck(gslbInfo.GslbHealthCheck.GslbSendString, gslbInfo.GslbHealthCheck.GslbResponseString)
+		if ok {
+			rd.Set("health_check", flattenHttpHealthCheck(healthCheck))
+		} else {
+			rd.Set("health_check", nil)
+		}
+	}
 
 	var gslbResourceInfo gslb2.ListResponseGslbResourceMappingResponse
 	gslbResourceInfo, err = inst.Client.Gslb.GetGslbResource(ctx, rd.Id())
 
+	// members added by samsungcloudplatform_gslb_resource_attachment are not owned by the GSLB.
+	// all members are read if none are owned yet. (e.g. on import)
+	ownedDestinations := getGslbResourceDestinations(rd.Get("gslb_resources").(*schema.Set).List())
+
 	var gslbResources []common.HclKeyValueObject
 
 	for _, gslbResource := range gslbResourceInfo.Contents {
+		if len(ownedDestinations) != 0 && !ownedDestinations[gslbResource.GslbDestination] {
+			continue
+		}
 		gslbResources = append(gslbResources, common.HclKeyValueObject{
 			"gslb_destination":          gslbResource.GslbDestination,
 			"gslb_region":               gslbResource.GslbRegion,
@@ -382,7 +443,7 @@ func resourceGslbUpdate(ctx context.Context, rd *schema.ResourceData, meta inter
 
 	}
 
-	if rd.HasChanges("protocol", "gslb_health_check_interval", "gslb_health_check_timeout", "probe_timeout", "service_port", "gslb_health_check_user_id", "gslb_send_string", "gslb_response_string") {
+	if rd.HasChanges("protocol", "gslb_health_check_interval", "gslb_health_check_timeout", "probe_timeout", "service_port", "gslb_health_check_user_id", "gslb_health_check_user_password", "gslb_send_string", "gslb_response_string") {
 		protocol := rd.Get("protocol").(string)
 		gslbHealthCheckInterval := int32(rd.Get("gslb_health_check_interval").(int))
 		gslbHealthCheckTimeout := int32(rd.Get("gslb_health_check_timeout").(int))
@@ -422,7 +483,8 @@ func resourceGslbUpdate(ctx context.Context, rd *schema.ResourceData, meta inter
 	}
 
 	if rd.HasChanges("gslb_resources") {
-		gslbResourceList := rd.Get("gslb_resources").(*schema.Set).List()
+		oldGslbResources, newGslbResources := rd.GetChange("gslb_resources")
+		gslbResourceList := newGslbResources.(*schema.Set).List()
 
 		gslbResources := make([]gslb2.GslbResourceMappingRequestVo, len(gslbResourceList))
 
@@ -451,12 +513,7 @@ func resourceGslbUpdate(ctx context.Context, rd *schema.ResourceData, meta inter
 			return diag.FromErr(validateErr)
 		}
 
-		_, _, err := inst.Client.Gslb.UpdateGslbResources(ctx, rd.Id(), gslbResources)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		err = waitForGslbStatus(ctx, inst.Client, rd.Id(), []string{"EDITING"}, []string{"ACTIVE"}, true)
+		err := updateOwnedGslbResources(ctx, inst, rd.Id(), oldGslbResources.(*schema.Set).List(), gslbResources)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -470,6 +527,121 @@ func resourceGslbUpdate(ctx context.Context, rd *schema.ResourceData, meta inter
 	return resourceGslbRead(ctx, rd, meta)
 }
 
+func getGslbResourceDestinations(gslbResourceList []interface{}) map[string]bool {
+	destinations := make(map[string]bool)
+	for _, gslbResource := range gslbResourceList {
+		destinations[gslbResource.(map[string]interface{})["gslb_destination"].(string)] = true
+	}
+	return destinations
+}
+
+// updateOwnedGslbResources replaces the members owned by the GSLB, and keeps the members added by attachments
+func updateOwnedGslbResources(ctx context.Context, inst *client.Instance, gslbId string, oldGslbResourceList []interface{}, gslbResources []gslb2.GslbResourceMappingRequestVo) error {
+	gslbResourcesMutexKV.Lock(gslbId)
+	defer gslbResourcesMutexKV.Unlock(gslbId)
+
+	current, err := getGslbResourceMappings(ctx, inst, gslbId)
+	if err != nil {
+		return err
+	}
+
+	ownedDestinations := getGslbResourceDestinations(oldGslbResourceList)
+	for _, gslbResource := range gslbResources {
+		ownedDestinations[gslbResource.GslbDestination] = true
+	}
+	for _, gslbResource := range current {
+		if !ownedDestinations[gslbResource.GslbDestination] {
+			gslbResources = append(gslbResources, gslbResource)
+		}
+	}
+
+	return updateGslbResourceMappings(ctx, inst, gslbId, gslbResources)
+}
+
+func expandHttpHealthCheck(list []interface{}) (httpHealthCheck, bool) {
+	if len(list) == 0 || list[0] == nil {
+		return httpHealthCheck{}, false
+	}
+	itemObject := list[0].(map[string]interface{})
+
+	healthCheck := httpHealthCheck{
+		Method:            itemObject["method"].(string),
+		Path:              itemObject["path"].(string),
+		Headers:           make(map[string]string),
+		ExpectedBodyRegex: itemObject["expected_body_regex"].(string),
+	}
+	for name, value := range itemObject["headers"].(map[string]interface{}) {
+		healthCheck.Headers[name] = value.(string)
+	}
+	for _, code := range itemObject["expected_status_codes"].([]interface{}) {
+		healthCheck.ExpectedStatusCodes = append(healthCheck.ExpectedStatusCodes, code.(int))
+	}
+	if len(healthCheck.ExpectedStatusCodes) == 0 {
+		healthCheck.ExpectedStatusCodes = []int{200}
+	}
+	return healthCheck, true
+}
+
+func flattenHttpHealthCheck(healthCheck httpHealthCheck) []common.HclKeyValueObject {
+	return []common.HclKeyValueObject{{
+		"method":                healthCheck.Method,
+		"path":                  healthCheck.Path,
+		"headers":               healthCheck.Headers,
+		"expected_status_codes": healthCheck.ExpectedStatusCodes,
+		"expected_body_regex":   healthCheck.ExpectedBodyRegex,
+	}}
+}
+
+// resourceGslbHealthCheckDiff renders health_check into the probe strings on plan.
+// The probe strings are computed for health_check, so they are cleared here when neither of them is configured.
+func resourceGslbHealthCheckDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("health_check") {
+		return nil
+	}
+	healthCheck, ok := expandHttpHealthCheck(diff.Get("health_check").([]interface{}))
+	if !ok {
+		return clearUnconfiguredProbeStrings(diff)
+	}
+
+	if protocol := diff.Get("protocol").(string); diff.NewValueKnown("protocol") && protocol != "HTTP" && protocol != "HTTPS" {
+		return fmt.Errorf("health_check can be used only with HTTP or HTTPS protocol")
+	}
+	rawConfig := diff.GetRawConfig()
+	if !rawConfig.IsNull() && (!rawConfig.GetAttr("gslb_send_string").IsNull() || !rawConfig.GetAttr("gslb_response_string").IsNull()) {
+		return fmt.Errorf("health_check can not be used with gslb_send_string and gslb_response_string")
+	}
+	if err := healthCheck.validate(); err != nil {
+		return err
+	}
+
+	if sendString := healthCheck.renderSendString(); diff.Get("gslb_send_string").(string) != sendString {
+		if err := diff.SetNew("gslb_send_string", sendString); err != nil {
+			return err
+		}
+	}
+	if responseString := healthCheck.renderResponseString(); diff.Get("gslb_response_string").(string) != responseString {
+		if err := diff.SetNew("gslb_response_string", responseString); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func clearUnconfiguredProbeStrings(diff *schema.ResourceDiff) error {
+	rawConfig := diff.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+	for _, key := range []string{"gslb_send_string", "gslb_response_string"} {
+		if rawConfig.GetAttr(key).IsNull() && len(diff.Get(key).(string)) != 0 {
+			if err := diff.SetNew(key, ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func resourceGslbDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	inst := meta.(*client.Instance)
@@ -0,0 +1,253 @@
+package gslb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/gslb2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// gslbResourcesMutexKV serializes the changes of the members of a GSLB, because the members are always replaced as a whole
+var gslbResourcesMutexKV = common.NewMutexKV()
+
+// errLastGslbResource is returned when the attachment is the only member left, which the GSLB can not lose
+var errLastGslbResource = errors.New("the last gslb_resource of a GSLB can not be removed")
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_gslb_resource_attachment", ResourceGslbResourceAttachment())
+}
+
+func ResourceGslbResourceAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGslbResourceAttachmentCreate,
+		ReadContext:   resourceGslbResourceAttachmentRead,
+		UpdateContext: resourceGslbResourceAttachmentUpdate,
+		DeleteContext: resourceGslbResourceAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGslbResourceAttachmentImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"gslb_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "GSLB Id",
+			},
+			"gslb_destination": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Gslb Resource Destination",
+				ValidateDiagFunc: validateIpv4,
+			},
+			"gslb_region": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Gslb Resource Region",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"KR-EAST-1", "KR-WEST-1", "KR-WEST-2"}, false)),
+			},
+			"gslb_resource_weight": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Gslb Resource Weight",
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			"gslb_resource_disable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Gslb Resource Disabled or not",
+			},
+			"gslb_resource_description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Gslb Resource Description",
+				ValidateFunc: validation.StringLenBetween(0, 100),
+			},
+		},
+		Description: "Provides a single member of an existing GSLB. Other members of the GSLB are not changed.",
+	}
+}
+
+// getGslbResourceMappings returns the current members of the GSLB in the update request form
+func getGslbResourceMappings(ctx context.Context, inst *client.Instance, gslbId string) ([]gslb2.GslbResourceMappingRequestVo, error) {
+	gslbResourceInfo, err := inst.Client.Gslb.GetGslbResource(ctx, gslbId)
+	if err != nil {
+		return nil, err
+	}
+
+	var gslbResources []gslb2.GslbResourceMappingRequestVo
+	for _, gslbResource := range gslbResourceInfo.Contents {
+		disable := gslbResource.GslbResourceDisable
+		gslbResources = append(gslbResources, gslb2.GslbResourceMappingRequestVo{
+			GslbDestination:         gslbResource.GslbDestination,
+			GslbRegion:              gslbResource.GslbRegion,
+			GslbResourceWeight:      gslbResource.GslbResourceWeight,
+			GslbResourceDisable:     &disable,
+			GslbResourceDescription: gslbResource.GslbResourceDescription,
+		})
+	}
+	return gslbResources, nil
+}
+
+func updateGslbResourceMappings(ctx context.Context, inst *client.Instance, gslbId string, gslbResources []gslb2.GslbResourceMappingRequestVo) error {
+	if len(gslbResources) == 0 {
+		return fmt.Errorf("There must be at least one gslb_resource in GSLB Service.")
+	}
+
+	err := waitForGslbStatus(ctx, inst.Client, gslbId, []string{"EDITING"}, []string{"ACTIVE"}, true)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = inst.Client.Gslb.UpdateGslbResources(ctx, gslbId, gslbResources)
+	if err != nil {
+		return err
+	}
+
+	return waitForGslbStatus(ctx, inst.Client, gslbId, []string{"EDITING"}, []string{"ACTIVE"}, true)
+}
+
+func expandGslbResourceAttachment(rd *schema.ResourceData) gslb2.GslbResourceMappingRequestVo {
+	disable := rd.Get("gslb_resource_disable").(bool)
+	return gslb2.GslbResourceMappingRequestVo{
+		GslbDestination:         rd.Get("gslb_destination").(string),
+		GslbRegion:              rd.Get("gslb_region").(string),
+		GslbResourceWeight:      int32(rd.Get("gslb_resource_weight").(int)),
+		GslbResourceDisable:     &disable,
+		GslbResourceDescription: rd.Get("gslb_resource_description").(string),
+	}
+}
+
+// applyGslbResourceAttachment replaces the member of the destination with the attachment, or removes it if remove is true
+func applyGslbResourceAttachment(ctx context.Context, rd *schema.ResourceData, meta interface{}, remove bool) error {
+	inst := meta.(*client.Instance)
+
+	gslbId := rd.Get("gslb_id").(string)
+	attachment := expandGslbResourceAttachment(rd)
+
+	gslbResourcesMutexKV.Lock(gslbId)
+	defer gslbResourcesMutexKV.Unlock(gslbId)
+
+	current, err := getGslbResourceMappings(ctx, inst, gslbId)
+	if err != nil {
+		return err
+	}
+
+	var gslbResources []gslb2.GslbResourceMappingRequestVo
+	found := false
+	for _, gslbResource := range current {
+		if gslbResource.GslbDestination != attachment.GslbDestination {
+			gslbResources = append(gslbResources, gslbResource)
+			continue
+		}
+		found = true
+	}
+
+	if remove {
+		if !found {
+			return nil
+		}
+		if len(gslbResources) == 0 {
+			return errLastGslbResource
+		}
+	} else {
+		if found && rd.IsNewResource() {
+			return fmt.Errorf("gslb destination %s already exists in GSLB %s. import it with %s/%s", attachment.GslbDestination, gslbId, gslbId, attachment.GslbDestination)
+		}
+		gslbResources = append(gslbResources, attachment)
+	}
+
+	return updateGslbResourceMappings(ctx, inst, gslbId, gslbResources)
+}
+
+func resourceGslbResourceAttachmentCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := applyGslbResourceAttachment(ctx, rd, meta, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rd.SetId(rd.Get("gslb_id").(string) + "/" + rd.Get("gslb_destination").(string))
+
+	return resourceGslbResourceAttachmentRead(ctx, rd, meta)
+}
+
+func resourceGslbResourceAttachmentRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	gslbResourceInfo, err := inst.Client.Gslb.GetGslbResource(ctx, rd.Get("gslb_id").(string))
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	for _, gslbResource := range gslbResourceInfo.Contents {
+		if gslbResource.GslbDestination == rd.Get("gslb_destination").(string) {
+			rd.Set("gslb_region", gslbResource.GslbRegion)
+			rd.Set("gslb_resource_weight", gslbResource.GslbResourceWeight)
+			rd.Set("gslb_resource_disable", gslbResource.GslbResourceDisable)
+			rd.Set("gslb_resource_description", gslbResource.GslbResourceDescription)
+			return nil
+		}
+	}
+
+	rd.SetId("")
+	return nil
+}
+
+func resourceGslbResourceAttachmentUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if rd.HasChanges("gslb_resource_weight", "gslb_resource_disable", "gslb_resource_description") {
+		err := applyGslbResourceAttachment(ctx, rd, meta, false)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceGslbResourceAttachmentRead(ctx, rd, meta)
+}
+
+func resourceGslbResourceAttachmentDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := applyGslbResourceAttachment(ctx, rd, meta, true)
+	if errors.Is(err, errLastGslbResource) {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "GSLB member is kept",
+			Detail:   fmt.Sprintf("gslb destination %s is the last member of GSLB %s and is left in place. Only the attachment is removed from the state.", rd.Get("gslb_destination").(string), rd.Get("gslb_id").(string)),
+		}}
+	}
+	if err != nil && !common.IsDeleted(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceGslbResourceAttachmentImport imports with "<gslb_id>/<gslb_destination>" format
+func resourceGslbResourceAttachmentImport(ctx context.Context, rd *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.Split(rd.Id(), "/")
+	if len(ids) != 2 || len(ids[0]) == 0 || len(ids[1]) == 0 {
+		return nil, fmt.Errorf("invalid import id %q. expected format is <gslb_id>/<gslb_destination>", rd.Id())
+	}
+
+	rd.Set("gslb_id", ids[0])
+	rd.Set("gslb_destination", ids[1])
+
+	return []*schema.ResourceData{rd}, nil
+}
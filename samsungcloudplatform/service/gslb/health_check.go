@@ -0,0 +1,150 @@
+package gslb
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// gslbProbeStringMaxLength is the maximum length of gslb_send_string and gslb_response_string
+const gslbProbeStringMaxLength = 300
+
+// gslbProbeLineBreak is the escaped line break of the probe send string
+const gslbProbeLineBreak = `\r\n`
+
+const gslbProbeStatusPrefix = `^HTTP/1\.[01] (`
+const gslbProbeBodySeparator = `[\s\S]*`
+
+var gslbProbeStatusCodesRegexp = regexp.MustCompile(`^\d{3}(\|\d{3})*$`)
+
+// httpHealthCheck is the structured form of the HTTP(S) probe of the GSLB health check
+type httpHealthCheck struct {
+	Method              string
+	Path                string
+	Headers             map[string]string
+	ExpectedStatusCodes []int
+	ExpectedBodyRegex   string
+}
+
+// renderSendString renders the request line and the headers of the probe.
+// HTTP/1.1 is used only if the Host header exists, because it is mandatory in HTTP/1.1.
+func (h httpHealthCheck) renderSendString() string {
+	version := "HTTP/1.0"
+	var headerNames []string
+	for name := range h.Headers {
+		if strings.EqualFold(name, "Host") {
+			version = "HTTP/1.1"
+		}
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s %s %s%s", h.Method, h.Path, version, gslbProbeLineBreak))
+	for _, name := range headerNames {
+		builder.WriteString(fmt.Sprintf("%s: %s%s", name, h.Headers[name], gslbProbeLineBreak))
+	}
+	builder.WriteString(gslbProbeLineBreak)
+	return builder.String()
+}
+
+// renderResponseString renders the regular expression which the probe response must match
+func (h httpHealthCheck) renderResponseString() string {
+	var codes []string
+	for _, code := range h.ExpectedStatusCodes {
+		codes = append(codes, strconv.Itoa(code))
+	}
+	response := gslbProbeStatusPrefix + strings.Join(codes, "|") + ")"
+	if len(h.ExpectedBodyRegex) != 0 {
+		response += gslbProbeBodySeparator + h.ExpectedBodyRegex
+	}
+	return response
+}
+
+func (h httpHealthCheck) validate() error {
+	if !regexp.MustCompile(`^[A-Z]+$`).MatchString(h.Method) {
+		return fmt.Errorf("health_check method must be an uppercase HTTP method : %s", h.Method)
+	}
+	if !strings.HasPrefix(h.Path, "/") || strings.ContainsAny(h.Path, " \r\n") {
+		return fmt.Errorf("health_check path must start with / and must not contain spaces : %s", h.Path)
+	}
+	for name, value := range h.Headers {
+		if !regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`).MatchString(name) {
+			return fmt.Errorf("health_check header name is invalid : %s", name)
+		}
+		if strings.ContainsAny(value, "\r\n") || strings.Contains(value, gslbProbeLineBreak) {
+			return fmt.Errorf("health_check header %s must not contain line breaks", name)
+		}
+	}
+	if len(h.ExpectedStatusCodes) == 0 {
+		return fmt.Errorf("health_check expected_status_codes must not be empty")
+	}
+	for _, code := range h.ExpectedStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("health_check expected status code %d is invalid", code)
+		}
+	}
+	if len(h.ExpectedBodyRegex) != 0 {
+		if _, err := regexp.Compile(h.ExpectedBodyRegex); err != nil {
+			return fmt.Errorf("health_check expected_body_regex is invalid : %w", err)
+		}
+	}
+	if length := len(h.renderSendString()); length > gslbProbeStringMaxLength {
+		return fmt.Errorf("health_check is rendered into a send string of %d characters, but it must be at most %d", length, gslbProbeStringMaxLength)
+	}
+	if length := len(h.renderResponseString()); length > gslbProbeStringMaxLength {
+		return fmt.Errorf("health_check is rendered into a response string of %d characters, but it must be at most %d", length, gslbProbeStringMaxLength)
+	}
+	return nil
+}
+
+// parseHttpHealthCheck parses the probe strings rendered by renderSendString and renderResponseString.
+// Returns false if the strings are not in the rendered form.
+func parseHttpHealthCheck(sendString string, responseString string) (httpHealthCheck, bool) {
+	var healthCheck httpHealthCheck
+
+	if !strings.HasSuffix(sendString, gslbProbeLineBreak+gslbProbeLineBreak) {
+		return healthCheck, false
+	}
+	lines := strings.Split(strings.TrimSuffix(sendString, gslbProbeLineBreak+gslbProbeLineBreak), gslbProbeLineBreak)
+
+	requestLine := strings.Split(lines[0], " ")
+	if len(requestLine) != 3 || !strings.HasPrefix(requestLine[2], "HTTP/1.") {
+		return healthCheck, false
+	}
+	healthCheck.Method = requestLine[0]
+	healthCheck.Path = requestLine[1]
+
+	healthCheck.Headers = make(map[string]string)
+	for _, line := range lines[1:] {
+		name, value, found := strings.Cut(line, ": ")
+		if !found {
+			return healthCheck, false
+		}
+		healthCheck.Headers[name] = value
+	}
+
+	if !strings.HasPrefix(responseString, gslbProbeStatusPrefix) {
+		return healthCheck, false
+	}
+	rest := strings.TrimPrefix(responseString, gslbProbeStatusPrefix)
+	end := strings.Index(rest, ")")
+	if end < 0 || !gslbProbeStatusCodesRegexp.MatchString(rest[:end]) {
+		return healthCheck, false
+	}
+	for _, code := range strings.Split(rest[:end], "|") {
+		value, _ := strconv.Atoi(code)
+		healthCheck.ExpectedStatusCodes = append(healthCheck.ExpectedStatusCodes, value)
+	}
+
+	rest = rest[end+1:]
+	if len(rest) != 0 {
+		if !strings.HasPrefix(rest, gslbProbeBodySeparator) {
+			return healthCheck, false
+		}
+		healthCheck.ExpectedBodyRegex = strings.TrimPrefix(rest, gslbProbeBodySeparator)
+	}
+	return healthCheck, true
+}
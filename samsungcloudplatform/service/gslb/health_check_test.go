@@ -0,0 +1,65 @@
+package gslb
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHttpHealthCheckRoundTrip(t *testing.T) {
+	cases := []httpHealthCheck{
+		{Method: "GET", Path: "/", Headers: map[string]string{}, ExpectedStatusCodes: []int{200}},
+		{
+			Method:              "HEAD",
+			Path:                "/healthz?full=1",
+			Headers:             map[string]string{"Host": "www.example.com", "User-Agent": "gslb-probe"},
+			ExpectedStatusCodes: []int{200, 204},
+			ExpectedBodyRegex:   `"status":\s*"(ok|UP)"`,
+		},
+	}
+
+	for _, healthCheck := range cases {
+		if err := healthCheck.validate(); err != nil {
+			t.Fatal(err)
+		}
+		parsed, ok := parseHttpHealthCheck(healthCheck.renderSendString(), healthCheck.renderResponseString())
+		if !ok {
+			t.Fatalf("failed to parse %s / %s", healthCheck.renderSendString(), healthCheck.renderResponseString())
+		}
+		if !reflect.DeepEqual(parsed, healthCheck) {
+			t.Errorf("round trip mismatch : %+v != %+v", parsed, healthCheck)
+		}
+	}
+}
+
+func TestHttpHealthCheckRender(t *testing.T) {
+	healthCheck := httpHealthCheck{Method: "GET", Path: "/health", Headers: map[string]string{"Host": "a.example.com"}, ExpectedStatusCodes: []int{200}, ExpectedBodyRegex: "OK"}
+	if send := healthCheck.renderSendString(); send != `GET /health HTTP/1.1\r\nHost: a.example.com\r\n\r\n` {
+		t.Errorf("unexpected send string : %s", send)
+	}
+	if response := healthCheck.renderResponseString(); response != `^HTTP/1\.[01] (200)[\s\S]*OK` {
+		t.Errorf("unexpected response string : %s", response)
+	}
+}
+
+func TestParseHttpHealthCheckUnknownForm(t *testing.T) {
+	if _, ok := parseHttpHealthCheck("GET / HTTP/1.0", "200 OK"); ok {
+		t.Error("hand written probe strings should not be parsed")
+	}
+}
+
+func TestHttpHealthCheckValidate(t *testing.T) {
+	invalid := []httpHealthCheck{
+		{Method: "get", Path: "/", ExpectedStatusCodes: []int{200}},
+		{Method: "GET", Path: "health", ExpectedStatusCodes: []int{200}},
+		{Method: "GET", Path: "/", ExpectedStatusCodes: []int{}},
+		{Method: "GET", Path: "/", ExpectedStatusCodes: []int{700}},
+		{Method: "GET", Path: "/", ExpectedStatusCodes: []int{200}, ExpectedBodyRegex: "("},
+		{Method: "GET", Path: "/" + strings.Repeat("a", 300), ExpectedStatusCodes: []int{200}},
+	}
+	for _, healthCheck := range invalid {
+		if err := healthCheck.validate(); err == nil {
+			t.Errorf("%+v should be invalid", healthCheck)
+		}
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/hpclitenew"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
 	tfTags "github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/service/tag"
+	hpclitenewsdk "github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/hpc-lite-new"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"golang.org/x/net/context"
 	"strings"
 	"time"
@@ -44,7 +46,7 @@ func ResourceHpcLiteNew() *schema.Resource {
 			"contract": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "HPC Lite(New) Contract",
+				Description: "HPC Lite(New) Contract. It is changed for all the servers.",
 			},
 			"hyper_threading_enabled": {
 				Type:        schema.TypeString,
@@ -118,7 +120,14 @@ func ResourceHpcLiteNew() *schema.Resource {
 							Type:        schema.TypeString,
 							Computed:    true,
 							Optional:    true,
-							Description: "HPC Lite(New) Server Detail ip address",
+							Description: "HPC Lite(New) Server Detail ip address. It can not be changed for an existing server.",
+						},
+						"state": {
+							Type:             schema.TypeString,
+							Computed:         true,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{common.RunningState, common.StoppedState}, false)),
+							Description:      "HPC Lite(New) Server state (RUNNING or STOPPED)",
 						},
 					},
 				},
@@ -134,9 +143,6 @@ func ResourceHpcLiteNew() *schema.Resource {
 				if diff.HasChange("co_service_zone_id") {
 					return fmt.Errorf("co_service_zone_id can't be modified.")
 				}
-				if diff.HasChange("contract") {
-					return fmt.Errorf("contract can't be modified.")
-				}
 				if diff.HasChange("hyper_threading_enabled") {
 					return fmt.Errorf("hyper_threading_enabled can't be modified.")
 				}
@@ -167,6 +173,12 @@ func ResourceHpcLiteNew() *schema.Resource {
 				if diff.HasChange("vlan_pool_cidr") {
 					return fmt.Errorf("vlan_pool_cidr can't be modified.")
 				}
+				if diff.HasChange("server_details") {
+					oldServerDetails, _ := diff.GetChange("server_details")
+					if err := checkServerIpAddressChanges(oldServerDetails.([]interface{}), getConfiguredServerIpAddresses(diff.GetRawConfig())); err != nil {
+						return err
+					}
+				}
 			}
 			return nil
 		},
@@ -223,18 +235,23 @@ func resourceHpcLiteNewCreate(ctx context.Context, rd *schema.ResourceData, meta
 
 	response, _, err := inst.Client.HpcLiteNew.CreateHpcLiteNew(ctx, request)
 	if err != nil {
-		diag.FromErr(err)
+		return
 	}
 
 	for _, serverId := range response.ResourceIdList {
 		err = waitForAllHpcLiteNewStatus(ctx, inst.Client, serverId, []string{common.CreatingState}, []string{common.RunningState}, true)
 		if err != nil {
-			diag.FromErr(err)
+			return
 		}
 	}
 
 	setResourceId(rd, response.ResourceIdList)
 
+	err = stopCreatedHpcLiteNewServers(ctx, rd, inst, response.ResourceIdList, getStoppedServerNames(rd.Get("server_details").([]interface{})))
+	if err != nil {
+		return
+	}
+
 	return resourceHpcLiteNewRead(ctx, rd, meta)
 }
 
@@ -254,7 +271,9 @@ func resourceHpcLiteNewRead(ctx context.Context, rd *schema.ResourceData, meta i
 		serverNameToServerDetail[serverDetail["server_name"].(string)] = serverDetail
 	}
 
+	// keep the order of server_details, and append the servers which are not in server_details (import)
 	var serverDetails []interface{}
+	var importedServerDetails []interface{}
 	serverIds := getServerIds(rd)
 	for _, serverId := range serverIds {
 		res, _, err := inst.Client.HpcLiteNew.GetHpcLiteNewDetail(ctx, serverId)
@@ -262,24 +281,26 @@ func resourceHpcLiteNewRead(ctx context.Context, rd *schema.ResourceData, meta i
 			rd.SetId("")
 			return diag.FromErr(err)
 		}
-		// not import
+		serverDetail := make(map[string]interface{})
+		serverDetail["id"] = serverId
+		serverDetail["server_name"] = res.ServerName
+		serverDetail["ip_address"] = res.IpAddress
+		serverDetail["state"] = strings.ToUpper(res.ServerState)
 		if _, hasKey := serverNameToServerDetail[res.ServerName]; hasKey {
-			serverDetail := serverNameToServerDetail[res.ServerName].(map[string]interface{})
-			serverDetail["id"] = serverId
-			if serverDetail["ip_address"] == nil || serverDetail["ip_address"] == "" {
-				serverDetail["ip_address"] = res.IpAddress
-			}
+			serverNameToServerDetail[res.ServerName] = serverDetail
 		} else {
-			serverDetail := make(map[string]interface{})
-			serverDetail["id"] = serverId
-			serverDetail["server_name"] = res.ServerName
-			serverDetail["ip_address"] = res.IpAddress
-			serverDetails = append(serverDetails, serverDetail)
+			importedServerDetails = append(importedServerDetails, serverDetail)
 		}
 	}
-	if len(serverDetails) > 0 {
-		rd.Set("server_details", serverDetails)
+	for _, server := range rd.Get("server_details").([]interface{}) {
+		serverName := server.(map[string]interface{})["server_name"].(string)
+		if serverDetail := serverNameToServerDetail[serverName].(map[string]interface{}); serverDetail["id"] != nil && serverDetail["id"] != "" {
+			serverDetails = append(serverDetails, serverDetail)
+		}
 	}
+	serverDetails = append(serverDetails, importedServerDetails...)
+	rd.Set("server_details", serverDetails)
+
 	res, _, err := inst.Client.HpcLiteNew.GetHpcLiteNewDetail(ctx, serverIds[0])
 	if _, exists := rd.GetOk("co_service_zone_id"); !exists {
 		rd.Set("co_service_zone_id", res.CoServiceZone)
@@ -324,42 +345,38 @@ func resourceHpcLiteNewUpdate(ctx context.Context, rd *schema.ResourceData, meta
 	}()
 	if rd.HasChanges("server_details") {
 		ov, nv := rd.GetChange("server_details")
-		oldServerDetails := ov.([]interface{})
-		newServerDetails := nv.([]interface{})
-
-		oldDetails, _ := mapServerNameToDetail(oldServerDetails)
-		newDetails, hasDuplicatedName := mapServerNameToDetail(newServerDetails)
-		if hasDuplicatedName {
-			errMsg := "Server Name is duplicated."
-			err := rd.Set("server_details", ov)
+
+		var changes hpcLiteNewServerChanges
+		changes, err = getHpcLiteNewServerChanges(ov.([]interface{}), nv.([]interface{}))
+		if err != nil {
+			return
+		}
+
+		if len(changes.RemovedServerIds) > 0 {
+			err = deleteHpcLiteNewServers(ctx, rd, changes.RemovedServerIds, inst)
 			if err != nil {
-				errMsg += err.Error()
+				return
 			}
-			return diag.Errorf(errMsg)
-		}
 
-		if len(oldDetails) > len(newDetails) {
-			var deleteServerIds []string
 			var notDeleteServerIds []string
-			for serverName := range oldDetails {
-				serverId := oldDetails[serverName].(map[string]interface{})["id"].(string)
-				if _, hasKey := newDetails[serverName]; hasKey {
+			for _, serverId := range getServerIds(rd) {
+				if !containsServerId(changes.RemovedServerIds, serverId) {
 					notDeleteServerIds = append(notDeleteServerIds, serverId)
-				} else {
-					deleteServerIds = append(deleteServerIds, serverId)
 				}
 			}
-
-			deleteHpcLiteNewServers(ctx, rd, deleteServerIds, inst)
 			setResourceId(rd, notDeleteServerIds)
-		} else if len(oldDetails) < len(newDetails) {
+		}
+
+		if len(changes.AddedServers) > 0 {
 			var serverDetailsRequestList []hpclitenew.ServerDetailRequest
-			for serverName := range newDetails {
-				if _, hasKey := oldDetails[serverName]; !hasKey {
-					serverDetailsRequestList = append(serverDetailsRequestList, hpclitenew.ServerDetailRequest{
-						ServerName: serverName,
-						IpAddress:  newDetails[serverName].(map[string]interface{})["ip_address"].(string),
-					})
+			var stoppedServerNames []string
+			for _, server := range changes.AddedServers {
+				serverDetailsRequestList = append(serverDetailsRequestList, hpclitenew.ServerDetailRequest{
+					ServerName: server.ServerName,
+					IpAddress:  server.IpAddress,
+				})
+				if server.State == common.StoppedState {
+					stoppedServerNames = append(stoppedServerNames, server.ServerName)
 				}
 			}
 			request := hpclitenew.HpcLiteNewCreateRequest{
@@ -378,73 +395,182 @@ func resourceHpcLiteNewUpdate(ctx context.Context, rd *schema.ResourceData, meta
 				Tags:                  rd.Get("tags").(map[string]interface{}),
 				VlanPoolCidr:          rd.Get("vlan_pool_cidr").(string),
 			}
-			response, _, err := inst.Client.HpcLiteNew.CreateHpcLiteNew(ctx, request)
+			var response hpclitenewsdk.AsyncListResponse
+			response, _, err = inst.Client.HpcLiteNew.CreateHpcLiteNew(ctx, request)
 			if err != nil {
-				diag.FromErr(err)
+				return
 			}
 			currentServerIds := getServerIds(rd)
 			for _, serverId := range response.ResourceIdList {
 				err = waitForAllHpcLiteNewStatus(ctx, inst.Client, serverId, []string{common.CreatingState}, []string{common.RunningState}, true)
 				if err != nil {
-					diag.FromErr(err)
+					return
 				}
 				currentServerIds = append(currentServerIds, serverId)
 			}
 			setResourceId(rd, currentServerIds)
+
+			err = stopCreatedHpcLiteNewServers(ctx, rd, inst, response.ResourceIdList, stoppedServerNames)
+			if err != nil {
+				return
+			}
+		}
+
+		if len(changes.StartServerIds) > 0 {
+			err = changeHpcLiteNewServersState(ctx, rd, inst, changes.StartServerIds, common.RunningState)
+			if err != nil {
+				return
+			}
+		}
+
+		if len(changes.StopServerIds) > 0 {
+			err = changeHpcLiteNewServersState(ctx, rd, inst, changes.StopServerIds, common.StoppedState)
+			if err != nil {
+				return
+			}
+		}
+	}
+	if rd.HasChanges("contract") {
+		serverIds := getServerIds(rd)
+		_, _, err = inst.Client.HpcLiteNew.UpdateHpcLiteNewContract(ctx, hpclitenew.HpcLiteNewContractUpdateRequest{
+			Contract:      rd.Get("contract").(string),
+			ServerIds:     serverIds,
+			ServiceZoneId: rd.Get("service_zone_id").(string),
+		})
+		if err != nil {
+			return
+		}
+		for _, serverId := range serverIds {
+			err = waitForAllHpcLiteNewStatus(ctx, inst.Client, serverId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, true)
+			if err != nil {
+				return
+			}
 		}
 	}
 	if rd.HasChanges("tags") {
 		serverIds := getServerIds(rd)
 		for _, serverId := range serverIds {
-			tfTags.UpdateTags(ctx, rd, meta, serverId)
+			err = tfTags.UpdateTags(ctx, rd, meta, serverId)
+			if err != nil {
+				return
+			}
 		}
 	}
 	return resourceHpcLiteNewRead(ctx, rd, meta)
 }
 
-func mapServerNameToDetail(serverDetails []interface{}) (map[string]interface{}, bool) {
-	nameToDetails := make(map[string]interface{})
+// changeHpcLiteNewServersState starts or stops the servers, and waits until they reach the state
+func changeHpcLiteNewServersState(ctx context.Context, rd *schema.ResourceData, inst *client.Instance, serverIds []string, state string) error {
+	for _, serverId := range serverIds {
+		err := waitForAllHpcLiteNewStatus(ctx, inst.Client, serverId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	request := hpclitenew.HpcLiteNewStartStopRequest{
+		ServerIds:     serverIds,
+		ServiceZoneId: rd.Get("service_zone_id").(string),
+	}
+	var err error
+	if state == common.StoppedState {
+		_, _, err = inst.Client.HpcLiteNew.StopHpcLiteNew(ctx, request)
+	} else {
+		_, _, err = inst.Client.HpcLiteNew.StartHpcLiteNew(ctx, request)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, serverId := range serverIds {
+		err = waitForAllHpcLiteNewStatus(ctx, inst.Client, serverId, common.VirtualServerProcessingStates(), []string{state}, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stopCreatedHpcLiteNewServers stops the created servers whose state is declared as STOPPED, as servers are created running
+func stopCreatedHpcLiteNewServers(ctx context.Context, rd *schema.ResourceData, inst *client.Instance, createdServerIds []string, stoppedServerNames []string) error {
+	if len(stoppedServerNames) == 0 {
+		return nil
+	}
+
+	var stopServerIds []string
+	for _, serverId := range createdServerIds {
+		res, _, err := inst.Client.HpcLiteNew.GetHpcLiteNewDetail(ctx, serverId)
+		if err != nil {
+			return err
+		}
+		if containsServerId(stoppedServerNames, res.ServerName) {
+			stopServerIds = append(stopServerIds, serverId)
+		}
+	}
+	if len(stopServerIds) == 0 {
+		return nil
+	}
+
+	return changeHpcLiteNewServersState(ctx, rd, inst, stopServerIds, common.StoppedState)
+}
+
+func getStoppedServerNames(serverDetails []interface{}) []string {
+	var serverNames []string
 	for _, v := range serverDetails {
 		serverDetail := v.(map[string]interface{})
-		nameToDetails[serverDetail["server_name"].(string)] = serverDetail
+		if getServerDetailString(serverDetail, "state") == common.StoppedState {
+			serverNames = append(serverNames, getServerDetailString(serverDetail, "server_name"))
+		}
 	}
-	hasDuplicatedName := len(nameToDetails) < len(serverDetails)
-	return nameToDetails, hasDuplicatedName
+	return serverNames
+}
+
+func containsServerId(serverIds []string, serverId string) bool {
+	for _, v := range serverIds {
+		if v == serverId {
+			return true
+		}
+	}
+	return false
 }
 
 func resourceHpcLiteNewDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
 	inst := meta.(*client.Instance)
 
 	deleteServerIds := strings.Split(rd.Id(), ",")
-	deleteHpcLiteNewServers(ctx, rd, deleteServerIds, inst)
+	err := deleteHpcLiteNewServers(ctx, rd, deleteServerIds, inst)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
-func deleteHpcLiteNewServers(ctx context.Context, rd *schema.ResourceData, deleteServerIds []string, inst *client.Instance) {
+func deleteHpcLiteNewServers(ctx context.Context, rd *schema.ResourceData, deleteServerIds []string, inst *client.Instance) error {
 	request := hpclitenew.HpcLiteNewDeleteRequest{
 		ServerIds:     deleteServerIds,
 		ServiceZoneId: rd.Get("service_zone_id").(string),
 	}
 
 	for _, serverId := range deleteServerIds {
-		err := waitForAllHpcLiteNewStatus(ctx, inst.Client, serverId, common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+		err := waitForAllHpcLiteNewStatus(ctx, inst.Client, serverId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, true)
 		if err != nil {
-			diag.FromErr(err)
+			return err
 		}
 	}
 
 	_, _, err := inst.Client.HpcLiteNew.DeleteHpcLiteNew(ctx, request)
 	if err != nil {
-		diag.FromErr(err)
+		return err
 	}
 
 	for _, serverId := range deleteServerIds {
 		err = waitForAllHpcLiteNewStatus(ctx, inst.Client, serverId, common.VirtualServerProcessingStates(), []string{common.DeletedState}, false)
 		if err != nil {
-			diag.FromErr(err)
+			return err
 		}
 	}
+	return nil
 }
 
 func waitForAllHpcLiteNewStatus(ctx context.Context, scpClient *client.SCPClient, serverId string, pendingStates []string, targetStates []string, checkNotFound bool) error {
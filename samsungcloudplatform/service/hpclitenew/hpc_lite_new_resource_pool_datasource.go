@@ -0,0 +1,97 @@
+package hpclitenew
+
+import (
+	"context"
+
+	scp "github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	uuid "github.com/satori/go.uuid"
+)
+
+func init() {
+	scp.RegisterDataSource("samsungcloudplatform_hpc_lite_new_resource_pools", DatasourceHpcLiteNewResourcePools())
+}
+
+func DatasourceHpcLiteNewResourcePools() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHpcLiteNewResourcePoolList,
+		Schema: map[string]*schema.Schema{
+			"service_zone_id": {Type: schema.TypeString, Required: true, Description: "HPC Lite(New) Service Zone ID"},
+			"contents":        {Type: schema.TypeList, Computed: true, Description: "HPC Lite(New) resource pool list", Elem: datasourceHpcLiteNewResourcePoolElem()},
+			"total_count":     {Type: schema.TypeInt, Computed: true, Description: "Total list size"},
+		},
+		Description: "Provides list of HPC Lite(New) resource pools and the usage of their VLAN pool CIDRs",
+	}
+}
+
+func dataSourceHpcLiteNewResourcePoolList(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	responses, _, err := inst.Client.HpcLiteNew.GetHpcLiteNewResourcePoolList(ctx, rd.Get("service_zone_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	contents := make([]map[string]interface{}, 0)
+	for _, resourcePool := range responses.Contents {
+		vlanPoolResponses, _, err := inst.Client.HpcLiteNew.GetHpcLiteNewVlanPoolList(ctx, resourcePool.ResourcePoolId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		vlanPools := make([]map[string]interface{}, 0)
+		for _, vlanPool := range vlanPoolResponses.Contents {
+			ipCount, err := getCidrIpCount(vlanPool.VlanPoolCidr)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			usedIpCount := int64(vlanPool.UsedIpCount)
+			vlanPools = append(vlanPools, map[string]interface{}{
+				"vlan_pool_cidr":     vlanPool.VlanPoolCidr,
+				"ip_count":           ipCount,
+				"used_ip_count":      usedIpCount,
+				"available_ip_count": ipCount - usedIpCount,
+			})
+		}
+
+		contents = append(contents, map[string]interface{}{
+			"resource_pool_id":   resourcePool.ResourcePoolId,
+			"resource_pool_name": resourcePool.ResourcePoolName,
+			"co_service_zone_id": resourcePool.CoServiceZoneId,
+			"server_type":        resourcePool.ServerType,
+			"vlan_pools":         vlanPools,
+		})
+	}
+
+	rd.SetId(uuid.NewV4().String())
+	rd.Set("contents", contents)
+	rd.Set("total_count", len(contents))
+
+	return nil
+}
+
+func datasourceHpcLiteNewResourcePoolElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"resource_pool_id":   {Type: schema.TypeString, Computed: true, Description: "HPC Lite(New) resource pool id"},
+			"resource_pool_name": {Type: schema.TypeString, Computed: true, Description: "HPC Lite(New) resource pool name"},
+			"co_service_zone_id": {Type: schema.TypeString, Computed: true, Description: "HPC Lite(New) CO Pool ID"},
+			"server_type":        {Type: schema.TypeString, Computed: true, Description: "HPC Lite(New) Server Type"},
+			"vlan_pools": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "VLAN pools of the resource pool",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vlan_pool_cidr":     {Type: schema.TypeString, Computed: true, Description: "HPC Lite(New) Vlan Pool CIDR"},
+						"ip_count":           {Type: schema.TypeInt, Computed: true, Description: "Number of ip addresses in the CIDR"},
+						"used_ip_count":      {Type: schema.TypeInt, Computed: true, Description: "Number of ip addresses in use"},
+						"available_ip_count": {Type: schema.TypeInt, Computed: true, Description: "Number of ip addresses left"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,142 @@
+package hpclitenew
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/hashicorp/go-cty/cty"
+)
+
+type hpcLiteNewServer struct {
+	ServerName string
+	IpAddress  string
+	State      string
+}
+
+// hpcLiteNewServerChanges are the changes of server_details. The servers are matched by server_name.
+type hpcLiteNewServerChanges struct {
+	AddedServers     []hpcLiteNewServer
+	RemovedServerIds []string
+	StartServerIds   []string
+	StopServerIds    []string
+}
+
+func getServerDetailString(serverDetail map[string]interface{}, key string) string {
+	if value, ok := serverDetail[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// getHpcLiteNewServerChanges compares the old and new server_details, so that only the changed servers are created, deleted, started or stopped
+func getHpcLiteNewServerChanges(oldServerDetails []interface{}, newServerDetails []interface{}) (hpcLiteNewServerChanges, error) {
+	changes := hpcLiteNewServerChanges{}
+
+	oldDetails, _ := mapServerNameToDetail(oldServerDetails)
+	newDetails, hasDuplicatedName := mapServerNameToDetail(newServerDetails)
+	if hasDuplicatedName {
+		return changes, fmt.Errorf("Server Name is duplicated.")
+	}
+
+	for _, v := range oldServerDetails {
+		oldDetail := v.(map[string]interface{})
+		if _, ok := newDetails[getServerDetailString(oldDetail, "server_name")]; !ok {
+			changes.RemovedServerIds = append(changes.RemovedServerIds, getServerDetailString(oldDetail, "id"))
+		}
+	}
+
+	for _, v := range newServerDetails {
+		newDetail := v.(map[string]interface{})
+		serverName := getServerDetailString(newDetail, "server_name")
+		newState := getServerDetailString(newDetail, "state")
+
+		oldValue, ok := oldDetails[serverName]
+		if !ok {
+			changes.AddedServers = append(changes.AddedServers, hpcLiteNewServer{
+				ServerName: serverName,
+				IpAddress:  getServerDetailString(newDetail, "ip_address"),
+				State:      newState,
+			})
+			continue
+		}
+
+		oldDetail := oldValue.(map[string]interface{})
+		if len(newState) == 0 || newState == getServerDetailString(oldDetail, "state") {
+			continue
+		}
+		if newState == common.StoppedState {
+			changes.StopServerIds = append(changes.StopServerIds, getServerDetailString(oldDetail, "id"))
+		} else {
+			changes.StartServerIds = append(changes.StartServerIds, getServerDetailString(oldDetail, "id"))
+		}
+	}
+
+	return changes, nil
+}
+
+// getConfiguredServerIpAddresses returns the ip_address of server_details by server_name, only for the servers which have ip_address in the config
+func getConfiguredServerIpAddresses(rawConfig cty.Value) map[string]string {
+	ipAddresses := make(map[string]string)
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return ipAddresses
+	}
+	serverDetails := rawConfig.GetAttr("server_details")
+	if serverDetails.IsNull() || !serverDetails.IsKnown() {
+		return ipAddresses
+	}
+	for it := serverDetails.ElementIterator(); it.Next(); {
+		_, serverDetail := it.Element()
+		if serverDetail.IsNull() || !serverDetail.IsKnown() {
+			continue
+		}
+		serverName := serverDetail.GetAttr("server_name")
+		ipAddress := serverDetail.GetAttr("ip_address")
+		if serverName.IsNull() || !serverName.IsKnown() || ipAddress.IsNull() || !ipAddress.IsKnown() || len(ipAddress.AsString()) == 0 {
+			continue
+		}
+		ipAddresses[serverName.AsString()] = ipAddress.AsString()
+	}
+	return ipAddresses
+}
+
+// checkServerIpAddressChanges rejects the ip_address changes of the existing servers, since the ip address of a server can't be modified
+func checkServerIpAddressChanges(oldServerDetails []interface{}, configuredIpAddresses map[string]string) error {
+	for _, v := range oldServerDetails {
+		oldDetail := v.(map[string]interface{})
+		serverName := getServerDetailString(oldDetail, "server_name")
+		ipAddress, ok := configuredIpAddresses[serverName]
+		if !ok {
+			continue
+		}
+		if oldIpAddress := getServerDetailString(oldDetail, "ip_address"); len(oldIpAddress) != 0 && oldIpAddress != ipAddress {
+			return fmt.Errorf("ip_address of server %s can't be modified from %s to %s. use a new server_name to replace the server.", serverName, oldIpAddress, ipAddress)
+		}
+	}
+	return nil
+}
+
+func mapServerNameToDetail(serverDetails []interface{}) (map[string]interface{}, bool) {
+	nameToDetails := make(map[string]interface{})
+	for _, v := range serverDetails {
+		serverDetail := v.(map[string]interface{})
+		nameToDetails[serverDetail["server_name"].(string)] = serverDetail
+	}
+	hasDuplicatedName := len(nameToDetails) < len(serverDetails)
+	return nameToDetails, hasDuplicatedName
+}
+
+// getCidrIpCount returns the number of ip addresses in the cidr
+func getCidrIpCount(cidr string) (int64, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return 0, err
+	}
+	ones, bits := ipNet.Mask.Size()
+	count := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	if !count.IsInt64() {
+		return 0, fmt.Errorf("cidr %s is too large", cidr)
+	}
+	return count.Int64(), nil
+}
@@ -0,0 +1,104 @@
+package hpclitenew
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestGetHpcLiteNewServerChanges(t *testing.T) {
+	oldServerDetails := []interface{}{
+		map[string]interface{}{"id": "SERVER-1", "server_name": "hpc01", "ip_address": "10.0.0.1", "state": "RUNNING"},
+		map[string]interface{}{"id": "SERVER-2", "server_name": "hpc02", "ip_address": "10.0.0.2", "state": "RUNNING"},
+		map[string]interface{}{"id": "SERVER-3", "server_name": "hpc03", "ip_address": "10.0.0.3", "state": "STOPPED"},
+	}
+	newServerDetails := []interface{}{
+		map[string]interface{}{"id": "SERVER-1", "server_name": "hpc01", "ip_address": "10.0.0.1", "state": "STOPPED"},
+		map[string]interface{}{"id": "SERVER-3", "server_name": "hpc03", "ip_address": "10.0.0.3", "state": "RUNNING"},
+		map[string]interface{}{"id": "", "server_name": "hpc04", "ip_address": "", "state": ""},
+	}
+
+	changes, err := getHpcLiteNewServerChanges(oldServerDetails, newServerDetails)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := hpcLiteNewServerChanges{
+		AddedServers:     []hpcLiteNewServer{{ServerName: "hpc04"}},
+		RemovedServerIds: []string{"SERVER-2"},
+		StartServerIds:   []string{"SERVER-3"},
+		StopServerIds:    []string{"SERVER-1"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %+v but %+v", expected, changes)
+	}
+
+	// reordering the servers is not a change
+	changes, err = getHpcLiteNewServerChanges(oldServerDetails, []interface{}{oldServerDetails[2], oldServerDetails[0], oldServerDetails[1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changes, hpcLiteNewServerChanges{}) {
+		t.Errorf("expected no change but %+v", changes)
+	}
+
+	_, err = getHpcLiteNewServerChanges(oldServerDetails, []interface{}{oldServerDetails[0], oldServerDetails[0]})
+	if err == nil {
+		t.Error("duplicated server name should not be allowed")
+	}
+}
+
+func TestCheckServerIpAddressChanges(t *testing.T) {
+	oldServerDetails := []interface{}{
+		map[string]interface{}{"id": "SERVER-1", "server_name": "hpc01", "ip_address": "10.0.0.1", "state": "RUNNING"},
+		map[string]interface{}{"id": "SERVER-2", "server_name": "hpc02", "ip_address": "10.0.0.2", "state": "RUNNING"},
+	}
+	serverDetail := func(serverName string, ipAddress cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"server_name": cty.StringVal(serverName),
+			"ip_address":  ipAddress,
+		})
+	}
+	rawConfig := func(serverDetails ...cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{"server_details": cty.ListVal(serverDetails)})
+	}
+
+	tests := []struct {
+		name        string
+		rawConfig   cty.Value
+		expectError bool
+	}{
+		{"same ip", rawConfig(serverDetail("hpc01", cty.StringVal("10.0.0.1")), serverDetail("hpc02", cty.NullVal(cty.String))), false},
+		{"reordered without ip", rawConfig(serverDetail("hpc02", cty.NullVal(cty.String)), serverDetail("hpc01", cty.NullVal(cty.String))), false},
+		{"new server with ip", rawConfig(serverDetail("hpc01", cty.NullVal(cty.String)), serverDetail("hpc03", cty.StringVal("10.0.0.3"))), false},
+		{"changed ip", rawConfig(serverDetail("hpc01", cty.NullVal(cty.String)), serverDetail("hpc02", cty.StringVal("10.0.0.5"))), true},
+		{"null config", cty.NullVal(cty.Object(map[string]cty.Type{"server_details": cty.List(cty.Object(map[string]cty.Type{"server_name": cty.String, "ip_address": cty.String}))})), false},
+	}
+	for _, test := range tests {
+		err := checkServerIpAddressChanges(oldServerDetails, getConfiguredServerIpAddresses(test.rawConfig))
+		if (err != nil) != test.expectError {
+			t.Errorf("%s : unexpected error %v", test.name, err)
+		}
+	}
+}
+
+func TestGetCidrIpCount(t *testing.T) {
+	tests := map[string]int64{
+		"172.24.159.0/24": 256,
+		"10.0.0.0/30":     4,
+		"10.0.0.1/32":     1,
+	}
+	for cidr, expected := range tests {
+		count, err := getCidrIpCount(cidr)
+		if err != nil {
+			t.Errorf("%s : %v", cidr, err)
+			continue
+		}
+		if count != expected {
+			t.Errorf("%s : expected %d but %d", cidr, expected, count)
+		}
+	}
+	if _, err := getCidrIpCount("172.24.159.0"); err == nil {
+		t.Error("invalid cidr should not be allowed")
+	}
+}
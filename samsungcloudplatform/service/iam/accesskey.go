@@ -4,10 +4,12 @@ import (
 	"context"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
 	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/crypto/openpgp"
 )
 
 func init() {
@@ -39,17 +41,35 @@ func ResourceAssessKey() *schema.Resource {
 			"access_key":        {Type: schema.TypeString, Computed: true, Description: "Access key"},
 			"access_key_id":     {Type: schema.TypeString, Computed: true, Description: "Access key ID"},
 			"access_key_state":  {Type: schema.TypeString, Computed: true, Description: "Access key state"},
-			"access_secret_key": {Type: schema.TypeString, Computed: true, Description: "Access secret key"},
+			"access_secret_key": {Type: schema.TypeString, Computed: true, Sensitive: true, Description: "Access secret key (empty when pgp_key is specified)"},
+			"pgp_key":           common.PgpKeySchema(),
+			"encrypted_secret":  {Type: schema.TypeString, Computed: true, Description: "Base64 encoded access secret key encrypted with pgp_key"},
+			"key_fingerprint":   {Type: schema.TypeString, Computed: true, Description: "Fingerprint of the PGP key used to encrypt the access secret key"},
 			"expired_dt":        {Type: schema.TypeString, Computed: true, Description: "Expired date"},
 			"project_name":      {Type: schema.TypeString, Computed: true, Description: "Project name"},
 		},
-		Description: "Provides IAM access key resource.",
+		CustomizeDiff: resourceAccessKeyDiff,
+		Description:   "Provides IAM access key resource.",
 	}
 }
 
+func resourceAccessKeyDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	return common.ValidatePgpKeyDiff(diff)
+}
+
 func resourceAccessKeyCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	inst := meta.(*client.Instance)
 
+	// The secret is returned only once, so the pgp key must be usable before the access key is created
+	var pgpEntity *openpgp.Entity
+	if pgpKey, ok := rd.GetOk("pgp_key"); ok {
+		entity, err := common.PreparePgpEntity(pgpKey.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		pgpEntity = entity
+	}
+
 	response, err := inst.Client.Iam.CreateAccessKey(ctx, rd.Get("project_id").(string), int32(rd.Get("duration_days").(int)))
 
 	if err != nil {
@@ -57,7 +77,17 @@ func resourceAccessKeyCreate(ctx context.Context, rd *schema.ResourceData, meta
 	}
 
 	rd.SetId(response.AccessKeyId)
-	rd.Set("access_secret_key", response.AccessSecretKey) // can only be set here
+	// secret can only be set here
+	if pgpEntity != nil {
+		fingerprint, encrypted, err := common.EncryptWithPgpEntity(pgpEntity, response.AccessSecretKey)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rd.Set("encrypted_secret", encrypted)
+		rd.Set("key_fingerprint", fingerprint)
+	} else {
+		rd.Set("access_secret_key", response.AccessSecretKey)
+	}
 	if *response.AccessKeyActivated {
 
 	}
@@ -87,7 +87,7 @@ func resourceCustomImageCreate(ctx context.Context, rd *schema.ResourceData, met
 		return diag.FromErr(err)
 	}
 
-	err = waitForCustomImageStatus(ctx, inst.Client, response.ResourceId, []string{}, []string{"ACTIVE"}, true)
+	err = WaitForCustomImageStatus(ctx, inst.Client, response.ResourceId, []string{}, []string{"ACTIVE"}, true)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -155,7 +155,7 @@ func resourceCustomImageDelete(ctx context.Context, rd *schema.ResourceData, met
 		return diag.FromErr(err)
 	}
 
-	err = waitForCustomImageStatus(ctx, inst.Client, rd.Id(), []string{}, []string{"DELETED"}, false)
+	err = WaitForCustomImageStatus(ctx, inst.Client, rd.Id(), []string{}, []string{"DELETED"}, false)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -163,7 +163,7 @@ func resourceCustomImageDelete(ctx context.Context, rd *schema.ResourceData, met
 	return nil
 }
 
-func waitForCustomImageStatus(ctx context.Context, scpClient *client.SCPClient, id string, pendingStates []string, targetStates []string, errorOnNotFound bool) error {
+func WaitForCustomImageStatus(ctx context.Context, scpClient *client.SCPClient, id string, pendingStates []string, targetStates []string, errorOnNotFound bool) error {
 	return client.WaitForStatus(ctx, scpClient, pendingStates, targetStates, func() (interface{}, string, error) {
 		info, c, err := scpClient.CustomImage.GetCustomImage(ctx, id)
 		if err != nil {
@@ -0,0 +1,136 @@
+package image
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	image "github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/image2"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func init() {
+	samsungcloudplatform.RegisterDataSource("samsungcloudplatform_latest_custom_image", DatasourceLatestCustomImage())
+}
+
+func DatasourceLatestCustomImage() *schema.Resource {
+	elem := elemCustomImage()
+	elem["image_id"] = &schema.Schema{Type: schema.TypeString, Computed: true}
+	elem["region"] = &schema.Schema{Type: schema.TypeString, Required: true, Description: "Region name"}
+	elem["name_regex"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+		Description:      "Regex which the image name must match",
+	}
+	elem["tags"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Tags which the image must have",
+	}
+
+	return &schema.Resource{
+		ReadContext: datasourceLatestCustomImageRead,
+		Schema:      elem,
+		Description: "Provides the latest active custom image which matches the name regex and tags",
+	}
+}
+
+func datasourceLatestCustomImageRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	serviceZoneId, err := getServiceZoneIdFromRegion(ctx, inst, rd.Get("region").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	versions, err := listCustomImageVersions(ctx, inst, serviceZoneId, rd.Get("name_regex").(string), rd.Get("tags").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(versions) == 0 {
+		return diag.Errorf("no matching custom image found")
+	}
+
+	responseCustomImage, _, err := inst.Client.CustomImage.GetCustomImage(ctx, versions[0].ImageId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rd.SetId(responseCustomImage.ImageId)
+	mapCustomImageDetail := common.ToMap(responseCustomImage)
+	for k := range elemCustomImage() {
+		if v, ok := mapCustomImageDetail[k]; ok {
+			rd.Set(k, v)
+		}
+	}
+	rd.Set("disks", common.ConvertStructToMaps(responseCustomImage.Disks))
+	rd.Set("products", common.ConvertStructToMaps(responseCustomImage.Products))
+
+	return nil
+}
+
+func getServiceZoneIdFromRegion(ctx context.Context, inst *client.Instance, region string) (string, error) {
+	serviceZoneId, _, err := client.FindServiceZoneIdAndProductGroupId(ctx, inst.Client, region, common.NetworkProductGroup, common.VpcProductName)
+	return serviceZoneId, err
+}
+
+// listCustomImageVersions returns the active custom images which match the name regex and tags, from the newest one
+func listCustomImageVersions(ctx context.Context, inst *client.Instance, serviceZoneId string, nameRegex string, tags map[string]interface{}) ([]customImageVersion, error) {
+	var regex *regexp.Regexp
+	if len(nameRegex) != 0 {
+		var err error
+		regex, err = regexp.Compile(nameRegex)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	responseCustomImages, err := inst.Client.CustomImage.GetCustomImageList(ctx, image.CustomImageV2ApiListCustomImagesOpts{
+		ImageState:    optional.NewString(ActiveState),
+		ServiceZoneId: optional.NewString(serviceZoneId),
+		Page:          optional.NewInt32(0),
+		Size:          optional.NewInt32(10000),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]customImageVersion, 0)
+	for _, content := range responseCustomImages.Contents {
+		versions = append(versions, customImageVersion{
+			ImageId:   content.ImageId,
+			ImageName: content.ImageName,
+			CreatedDt: content.CreatedDt,
+		})
+	}
+	versions = filterCustomImageVersionsByName(versions, regex)
+
+	// the tags are read only for the images which match the name regex
+	if len(tags) != 0 {
+		tagFilter := make(map[string]string)
+		for key, value := range tags {
+			tagFilter[key] = value.(string)
+		}
+		for i := range versions {
+			tagList, _, err := inst.Client.Tag.ListResourceTags(ctx, versions[i].ImageId)
+			if err != nil {
+				return nil, err
+			}
+			versions[i].Tags = make(map[string]string)
+			for _, tag := range tagList.Contents {
+				versions[i].Tags[tag.TagKey] = tag.TagValue
+			}
+		}
+		versions = filterCustomImageVersionsByTags(versions, tagFilter)
+	}
+
+	sortCustomImageVersions(versions)
+	return versions, nil
+}
@@ -0,0 +1,68 @@
+package image
+
+import (
+	"regexp"
+	"sort"
+	"time"
+)
+
+type customImageVersion struct {
+	ImageId   string
+	ImageName string
+	CreatedDt time.Time
+	Tags      map[string]string
+}
+
+func matchesImageTags(imageTags map[string]string, tags map[string]string) bool {
+	for key, value := range tags {
+		if imageValue, ok := imageTags[key]; !ok || imageValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// sortCustomImageVersions sorts the images from the newest one
+func sortCustomImageVersions(versions []customImageVersion) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].CreatedDt.After(versions[j].CreatedDt)
+	})
+}
+
+func filterCustomImageVersionsByName(versions []customImageVersion, nameRegex *regexp.Regexp) []customImageVersion {
+	filtered := make([]customImageVersion, 0)
+	for _, version := range versions {
+		if nameRegex == nil || nameRegex.MatchString(version.ImageName) {
+			filtered = append(filtered, version)
+		}
+	}
+	return filtered
+}
+
+func filterCustomImageVersionsByTags(versions []customImageVersion, tags map[string]string) []customImageVersion {
+	filtered := make([]customImageVersion, 0)
+	for _, version := range versions {
+		if matchesImageTags(version.Tags, tags) {
+			filtered = append(filtered, version)
+		}
+	}
+	return filtered
+}
+
+// getExpiredCustomImageIds returns the images which are not in the newest keepVersions images or are older than maxAgeDays.
+// The versions must be sorted from the newest one, and a zero keepVersions or maxAgeDays is not applied.
+// The referenced images are never expired.
+func getExpiredCustomImageIds(versions []customImageVersion, keepVersions int, maxAgeDays int, now time.Time, referencedImageIds map[string]bool) []string {
+	expiredImageIds := make([]string, 0)
+	for i, version := range versions {
+		if referencedImageIds[version.ImageId] {
+			continue
+		}
+		exceedsVersions := keepVersions > 0 && i >= keepVersions
+		exceedsAge := maxAgeDays > 0 && now.Sub(version.CreatedDt) > time.Duration(maxAgeDays)*24*time.Hour
+		if exceedsVersions || exceedsAge {
+			expiredImageIds = append(expiredImageIds, version.ImageId)
+		}
+	}
+	return expiredImageIds
+}
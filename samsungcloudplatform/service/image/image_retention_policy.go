@@ -0,0 +1,297 @@
+package image
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/virtualserver"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/autoscaling2"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_image_retention_policy", ResourceImageRetentionPolicy())
+}
+
+func ResourceImageRetentionPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceImageRetentionPolicyCreate,
+		ReadContext:   resourceImageRetentionPolicyRead,
+		UpdateContext: resourceImageRetentionPolicyUpdate,
+		DeleteContext: resourceImageRetentionPolicyDelete,
+		CustomizeDiff: resourceImageRetentionPolicyDiff,
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Region name",
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description:      "Regex which the names of the managed custom images must match",
+			},
+			"image_tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags which the managed custom images must have",
+			},
+			"keep_versions": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				AtLeastOneOf:     []string{"keep_versions", "max_age_days"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "Number of the newest custom images to keep",
+			},
+			"max_age_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				AtLeastOneOf:     []string{"keep_versions", "max_age_days"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "Custom images older than the days are deleted",
+			},
+			"matched_image_ids":    {Type: schema.TypeList, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}, Description: "Custom images which match the name regex and tags, from the newest one"},
+			"referenced_image_ids": {Type: schema.TypeList, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}, Description: "Matched custom images which are used by launch configurations or virtual servers"},
+			"expired_image_ids":    {Type: schema.TypeList, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}, Description: "Custom images which are deleted by the planned apply"},
+			"deleted_image_ids":    {Type: schema.TypeList, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}, Description: "Custom images deleted by the last apply"},
+		},
+		Description: "Deletes the custom images which are not in the newest versions or are older than the days. The images used by launch configurations or virtual servers are not deleted. Other consumers of an image, such as Kubernetes node pools or images shared with other projects, are not checked.",
+	}
+}
+
+type imageRetentionStatus struct {
+	MatchedImageIds    []string
+	ReferencedImageIds []string
+	ExpiredImageIds    []string
+}
+
+// imageRetentionPolicyInputs are the arguments which decide the expired custom images
+var imageRetentionPolicyInputs = []string{"region", "name_regex", "image_tags", "keep_versions", "max_age_days"}
+
+// attributeGetter is implemented by both schema.ResourceData and schema.ResourceDiff
+type attributeGetter interface {
+	Get(key string) interface{}
+}
+
+// getImageRetentionStatus finds the expired custom images. The image references are checked with the launch configurations and virtual servers.
+func getImageRetentionStatus(ctx context.Context, rd attributeGetter, inst *client.Instance) (imageRetentionStatus, error) {
+	status := imageRetentionStatus{
+		MatchedImageIds:    make([]string, 0),
+		ReferencedImageIds: make([]string, 0),
+	}
+
+	serviceZoneId, err := getServiceZoneIdFromRegion(ctx, inst, rd.Get("region").(string))
+	if err != nil {
+		return status, err
+	}
+
+	versions, err := listCustomImageVersions(ctx, inst, serviceZoneId, rd.Get("name_regex").(string), rd.Get("image_tags").(map[string]interface{}))
+	if err != nil {
+		return status, err
+	}
+
+	usedImageIds, err := getUsedImageIds(ctx, inst)
+	if err != nil {
+		return status, err
+	}
+
+	referencedImageIds := make(map[string]bool)
+	for _, version := range versions {
+		status.MatchedImageIds = append(status.MatchedImageIds, version.ImageId)
+		if usedImageIds[version.ImageId] {
+			referencedImageIds[version.ImageId] = true
+			status.ReferencedImageIds = append(status.ReferencedImageIds, version.ImageId)
+		}
+	}
+
+	status.ExpiredImageIds = getExpiredCustomImageIds(versions, rd.Get("keep_versions").(int), rd.Get("max_age_days").(int), time.Now(), referencedImageIds)
+	return status, nil
+}
+
+// imageReferenceListPageSize is the page size of the lists read to find the images in use
+const imageReferenceListPageSize = 1000
+
+// getUsedImageIds returns the images used by launch configurations and virtual servers.
+// Every page is read, since an image used by a server on a page not read would be deleted.
+// The list of virtual servers does not have the image id, so the detail of each virtual server is read.
+// Other consumers such as Kubernetes node pools or images shared with other projects are not checked.
+func getUsedImageIds(ctx context.Context, inst *client.Instance) (map[string]bool, error) {
+	usedImageIds := make(map[string]bool)
+
+	for page, listed := int32(0), 0; ; page++ {
+		launchConfigurations, _, err := inst.Client.AutoScaling.GetLaunchConfigurationList(ctx, &autoscaling2.AsgLaunchConfigurationV2ApiGetLaunchConfigListV2Opts{
+			Page: optional.NewInt32(page),
+			Size: optional.NewInt32(imageReferenceListPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, launchConfiguration := range launchConfigurations.Contents {
+			usedImageIds[launchConfiguration.ImageId] = true
+		}
+		listed += len(launchConfigurations.Contents)
+		if len(launchConfigurations.Contents) == 0 || listed >= int(launchConfigurations.TotalCount) {
+			break
+		}
+	}
+
+	request := virtualserver.ListVirtualServersRequestParam{
+		Size: imageReferenceListPageSize,
+		Sort: "createdDt:asc",
+	}
+	for listed := 0; ; request.Page++ {
+		virtualServers, err := inst.Client.VirtualServer.ListVirtualServers(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, virtualServer := range virtualServers.Contents {
+			virtualServerInfo, _, err := inst.Client.VirtualServer.GetVirtualServer(ctx, virtualServer.VirtualServerId)
+			if err != nil {
+				if common.IsDeleted(err) {
+					continue
+				}
+				return nil, err
+			}
+			usedImageIds[virtualServerInfo.ImageId] = true
+		}
+		listed += len(virtualServers.Contents)
+		if len(virtualServers.Contents) == 0 || listed >= int(virtualServers.TotalCount) {
+			break
+		}
+	}
+
+	return usedImageIds, nil
+}
+
+// applyImageRetentionPolicy deletes the expired images listed in the plan.
+// The references are checked again, so an image used since the plan is kept.
+func applyImageRetentionPolicy(ctx context.Context, rd *schema.ResourceData, inst *client.Instance) error {
+	deletedImageIds := make([]string, 0)
+	defer func() {
+		rd.Set("deleted_image_ids", deletedImageIds)
+	}()
+
+	plannedImageIds := common.ToStringList(rd.Get("expired_image_ids").([]interface{}))
+	if len(plannedImageIds) == 0 {
+		return nil
+	}
+
+	usedImageIds, err := getUsedImageIds(ctx, inst)
+	if err != nil {
+		return err
+	}
+
+	for _, imageId := range plannedImageIds {
+		if usedImageIds[imageId] {
+			log.Printf("[WARN] custom image %s is kept because it is used since the plan", imageId)
+			continue
+		}
+		err = inst.Client.CustomImage.DeleteCustomImage(ctx, imageId)
+		if err != nil {
+			if common.IsDeleted(err) {
+				continue
+			}
+			return err
+		}
+		err = WaitForCustomImageStatus(ctx, inst.Client, imageId, []string{}, []string{common.DeletedState}, false)
+		if err != nil {
+			return err
+		}
+		deletedImageIds = append(deletedImageIds, imageId)
+	}
+
+	return nil
+}
+
+// resourceImageRetentionPolicyDiff lists the images to be deleted in the plan.
+// It is recomputed when a policy input changes, or when the refresh found expired images.
+func resourceImageRetentionPolicyDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if len(diff.Id()) != 0 && !diff.HasChanges(imageRetentionPolicyInputs...) && len(diff.Get("expired_image_ids").([]interface{})) == 0 {
+		return nil
+	}
+
+	for _, key := range []string{"matched_image_ids", "referenced_image_ids", "deleted_image_ids"} {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range imageRetentionPolicyInputs {
+		if !diff.NewValueKnown(key) {
+			// nothing is deleted by this apply, the expired images are listed by the next plan
+			return diff.SetNew("expired_image_ids", []string{})
+		}
+	}
+
+	inst := meta.(*client.Instance)
+	status, err := getImageRetentionStatus(ctx, diff, inst)
+	if err != nil {
+		return err
+	}
+	return diff.SetNew("expired_image_ids", status.ExpiredImageIds)
+}
+
+func setImageRetentionStatus(rd *schema.ResourceData, status imageRetentionStatus) {
+	rd.Set("matched_image_ids", status.MatchedImageIds)
+	rd.Set("referenced_image_ids", status.ReferencedImageIds)
+}
+
+func resourceImageRetentionPolicyCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	rd.SetId(uuid.NewV4().String())
+
+	return resourceImageRetentionPolicyApply(ctx, rd, inst)
+}
+
+// resourceImageRetentionPolicyApply deletes the planned images. expired_image_ids keeps the planned value until the next refresh.
+func resourceImageRetentionPolicyApply(ctx context.Context, rd *schema.ResourceData, inst *client.Instance) diag.Diagnostics {
+	err := applyImageRetentionPolicy(ctx, rd, inst)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	status, err := getImageRetentionStatus(ctx, rd, inst)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setImageRetentionStatus(rd, status)
+
+	return nil
+}
+
+func resourceImageRetentionPolicyRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	status, err := getImageRetentionStatus(ctx, rd, inst)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setImageRetentionStatus(rd, status)
+	rd.Set("expired_image_ids", status.ExpiredImageIds)
+
+	return nil
+}
+
+func resourceImageRetentionPolicyUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	return resourceImageRetentionPolicyApply(ctx, rd, inst)
+}
+
+func resourceImageRetentionPolicyDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// the remaining custom images are kept
+	return nil
+}
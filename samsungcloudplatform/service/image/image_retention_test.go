@@ -0,0 +1,63 @@
+package image
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestGetExpiredCustomImageIds(t *testing.T) {
+	now := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	versions := []customImageVersion{
+		{ImageId: "IMAGE-1", ImageName: "web-1", CreatedDt: now.AddDate(0, 0, -40)},
+		{ImageId: "IMAGE-4", ImageName: "web-4", CreatedDt: now.AddDate(0, 0, -1)},
+		{ImageId: "IMAGE-2", ImageName: "web-2", CreatedDt: now.AddDate(0, 0, -20)},
+		{ImageId: "IMAGE-3", ImageName: "web-3", CreatedDt: now.AddDate(0, 0, -10)},
+	}
+	sortCustomImageVersions(versions)
+	if versions[0].ImageId != "IMAGE-4" || versions[3].ImageId != "IMAGE-1" {
+		t.Fatalf("images should be sorted from the newest one : %v", versions)
+	}
+
+	testCases := []struct {
+		keepVersions int
+		maxAgeDays   int
+		referenced   map[string]bool
+		expected     []string
+	}{
+		{2, 0, nil, []string{"IMAGE-2", "IMAGE-1"}},
+		{0, 15, nil, []string{"IMAGE-2", "IMAGE-1"}},
+		{3, 30, nil, []string{"IMAGE-1"}},
+		{1, 0, map[string]bool{"IMAGE-2": true}, []string{"IMAGE-3", "IMAGE-1"}},
+		{10, 0, nil, []string{}},
+	}
+	for _, tc := range testCases {
+		expired := getExpiredCustomImageIds(versions, tc.keepVersions, tc.maxAgeDays, now, tc.referenced)
+		if !reflect.DeepEqual(expired, tc.expected) {
+			t.Errorf("keep_versions %d, max_age_days %d : expected %v but %v", tc.keepVersions, tc.maxAgeDays, tc.expected, expired)
+		}
+	}
+}
+
+func TestFilterCustomImageVersions(t *testing.T) {
+	versions := []customImageVersion{
+		{ImageId: "IMAGE-1", ImageName: "web-1", Tags: map[string]string{"env": "prod"}},
+		{ImageId: "IMAGE-2", ImageName: "web-2", Tags: map[string]string{"env": "dev"}},
+		{ImageId: "IMAGE-3", ImageName: "db-1", Tags: map[string]string{"env": "prod"}},
+	}
+
+	filtered := filterCustomImageVersionsByName(versions, regexp.MustCompile("^web-"))
+	if len(filtered) != 2 {
+		t.Fatalf("expected 2 images but %d", len(filtered))
+	}
+
+	filtered = filterCustomImageVersionsByTags(filtered, map[string]string{"env": "prod"})
+	if len(filtered) != 1 || filtered[0].ImageId != "IMAGE-1" {
+		t.Errorf("unexpected images %v", filtered)
+	}
+
+	if len(filterCustomImageVersionsByName(versions, nil)) != 3 {
+		t.Error("all images should be matched without the regex")
+	}
+}
@@ -2,6 +2,7 @@ package keypair
 
 import (
 	"context"
+	"fmt"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/keypair"
@@ -9,6 +10,7 @@ import (
 	tfTags "github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/service/tag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/openpgp"
 )
 
 func init() {
@@ -35,14 +37,47 @@ func ResourceKeyPair() *schema.Resource {
 			"private_key": {
 				Type:             schema.TypeString,
 				Computed:         true,
+				Sensitive:        true,
 				ValidateDiagFunc: nil,
-				Description:      "Private Key",
+				Description:      "Private Key (empty when pgp_key or public_key is specified)",
+			},
+			"public_key": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validatePublicKey,
+				DiffSuppressFunc: suppressPublicKeyDiff,
+				Description:      "Existing public key to import in OpenSSH or PEM format. When specified, the key pair is not generated by the cloud and no private key is returned.",
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA256 fingerprint of the public key (SHA256:...)",
+			},
+			"pgp_key": common.PgpKeySchema(),
+			"encrypted_private_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Base64 encoded private key encrypted with pgp_key",
+			},
+			"key_fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Fingerprint of the PGP key used to encrypt the private key",
 			},
 			"tags": tfTags.TagsSchema(),
 		},
+		CustomizeDiff: resourceKeyPairDiff,
 	}
 }
 
+func resourceKeyPairDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if len(diff.Get("public_key").(string)) != 0 && len(diff.Get("pgp_key").(string)) != 0 {
+		return fmt.Errorf("pgp_key can not be used with public_key, because no private key is returned for the imported key")
+	}
+	return common.ValidatePgpKeyDiff(diff)
+}
+
 func resourceKeyPairCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
 	var err error = nil
 	defer func() {
@@ -55,6 +90,20 @@ func resourceKeyPairCreate(ctx context.Context, rd *schema.ResourceData, meta in
 
 	keyPairName := rd.Get("key_pair_name").(string)
 
+	if publicKey, ok := rd.GetOk("public_key"); ok {
+		err = resourceKeyPairImport(ctx, rd, inst, keyPairName, publicKey.(string))
+		return
+	}
+
+	// The private key is returned only once, so the pgp key must be usable before the key pair is created
+	var pgpEntity *openpgp.Entity
+	if pgpKey, ok := rd.GetOk("pgp_key"); ok {
+		pgpEntity, err = common.PreparePgpEntity(pgpKey.(string))
+		if err != nil {
+			return
+		}
+	}
+
 	response, err := inst.Client.KeyPair.CreateKeyPair(ctx, keypair.CreateRequest{
 		KeyPairName: keyPairName,
 		Tags:        rd.Get("tags").(map[string]interface{}),
@@ -69,7 +118,52 @@ func resourceKeyPairCreate(ctx context.Context, rd *schema.ResourceData, meta in
 	}
 
 	rd.SetId(response.KeyPairId)
-	rd.Set("private_key", response.PrivateKey)
+	if fingerprint, fingerprintErr := privateKeyFingerprint(response.PrivateKey); fingerprintErr == nil {
+		rd.Set("fingerprint", fingerprint)
+	}
+	if pgpEntity != nil {
+		fingerprint, encrypted, encryptErr := common.EncryptWithPgpEntity(pgpEntity, response.PrivateKey)
+		if encryptErr != nil {
+			err = encryptErr
+			return
+		}
+		rd.Set("encrypted_private_key", encrypted)
+		rd.Set("key_fingerprint", fingerprint)
+	} else {
+		rd.Set("private_key", response.PrivateKey)
+	}
+	rd.Set("key_pair_name", response.KeyPairName)
+
+	return nil
+}
+
+// resourceKeyPairImport registers the existing public key instead of generating a new key pair
+func resourceKeyPairImport(ctx context.Context, rd *schema.ResourceData, inst *client.Instance, keyPairName string, publicKey string) error {
+	normalized, err := normalizePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := publicKeyFingerprint(normalized)
+	if err != nil {
+		return err
+	}
+
+	response, err := inst.Client.KeyPair.ImportKeyPair(ctx, keypair.ImportRequest{
+		KeyPairName: keyPairName,
+		PublicKey:   normalized,
+		Tags:        rd.Get("tags").(map[string]interface{}),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = WaitForKeyPairStatus(ctx, inst.Client, response.KeyPairId, []string{}, []string{common.ActiveState}, true)
+	if err != nil {
+		return err
+	}
+
+	rd.SetId(response.KeyPairId)
+	rd.Set("fingerprint", fingerprint)
 	rd.Set("key_pair_name", response.KeyPairName)
 
 	return nil
@@ -95,6 +189,14 @@ func resourceKeyPairRead(ctx context.Context, rd *schema.ResourceData, meta inte
 	}
 
 	rd.Set("key_pair_name", keyPairInfo.KeyPairName)
+	if len(keyPairInfo.PublicKey) != 0 {
+		if _, ok := rd.GetOk("public_key"); ok {
+			rd.Set("public_key", keyPairInfo.PublicKey)
+		}
+		if fingerprint, fingerprintErr := publicKeyFingerprint(keyPairInfo.PublicKey); fingerprintErr == nil {
+			rd.Set("fingerprint", fingerprint)
+		}
+	}
 	tfTags.SetTags(ctx, rd, meta, rd.Id())
 
 	return nil
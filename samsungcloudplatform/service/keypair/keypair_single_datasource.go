@@ -0,0 +1,100 @@
+package keypair
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/keypair"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func init() {
+	samsungcloudplatform.RegisterDataSource("samsungcloudplatform_key_pair", DatasourceKeyPair())
+}
+
+func DatasourceKeyPair() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeyPairRead,
+		Schema: map[string]*schema.Schema{
+			"key_pair_id":    {Type: schema.TypeString, Optional: true, Computed: true, Description: "Key Pair Id"},
+			"key_pair_name":  {Type: schema.TypeString, Optional: true, Computed: true, Description: "Key Pair Name"},
+			"key_pair_state": {Type: schema.TypeString, Computed: true, Description: "Key Pair State"},
+			"public_key":     {Type: schema.TypeString, Computed: true, Description: "Public key in OpenSSH format"},
+			"fingerprint":    {Type: schema.TypeString, Computed: true, Description: "SHA256 fingerprint of the public key (SHA256:...)"},
+			"virtual_server_id_list": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Virtual Server Id List",
+			},
+			"created_by": {Type: schema.TypeString, Computed: true, Description: "Person who created the resource"},
+			"created_dt": {Type: schema.TypeString, Computed: true, Description: "Creation time"},
+		},
+		Description: "Provides a key pair with the fingerprint of its public key",
+	}
+}
+
+func dataSourceKeyPairRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	keyPairId := rd.Get("key_pair_id").(string)
+	keyPairName := rd.Get("key_pair_name").(string)
+
+	if len(keyPairId) == 0 {
+		if len(keyPairName) == 0 {
+			return diag.Errorf("one of key_pair_id or key_pair_name must be specified")
+		}
+
+		responses, err := inst.Client.KeyPair.ListKeyPairs(ctx, keypair.ListKeyPairsRequestParam{
+			KeyPairName: keyPairName,
+			Page:        0,
+			Size:        10000,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// key pair name filter of the list api is a partial match
+		var matched []string
+		for _, content := range responses.Contents {
+			if content.KeyPairName == keyPairName {
+				matched = append(matched, content.KeyPairId)
+			}
+		}
+		if len(matched) == 0 {
+			return diag.Errorf("key pair %s was not found", keyPairName)
+		}
+		if len(matched) > 1 {
+			return diag.Errorf("%d key pairs named %s were found", len(matched), keyPairName)
+		}
+		keyPairId = matched[0]
+	}
+
+	keyPairInfo, _, err := inst.Client.KeyPair.GetKeyPair(ctx, keyPairId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fingerprint := ""
+	if len(keyPairInfo.PublicKey) != 0 {
+		fingerprint, err = publicKeyFingerprint(keyPairInfo.PublicKey)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to fingerprint public key of key pair %s : %w", keyPairId, err))
+		}
+	}
+
+	rd.SetId(keyPairInfo.KeyPairId)
+	rd.Set("key_pair_id", keyPairInfo.KeyPairId)
+	rd.Set("key_pair_name", keyPairInfo.KeyPairName)
+	rd.Set("key_pair_state", keyPairInfo.KeyPairState)
+	rd.Set("public_key", keyPairInfo.PublicKey)
+	rd.Set("fingerprint", fingerprint)
+	rd.Set("virtual_server_id_list", keyPairInfo.VirtualServerIdList)
+	rd.Set("created_by", keyPairInfo.CreatedBy)
+	rd.Set("created_dt", keyPairInfo.CreatedDt.String())
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package keypair
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/ssh"
+)
+
+// parsePublicKey parses an OpenSSH authorized_keys line or a PEM encoded (PKIX or PKCS#1) public key
+func parsePublicKey(publicKey string) (ssh.PublicKey, error) {
+	publicKey = strings.TrimSpace(publicKey)
+	if len(publicKey) == 0 {
+		return nil, fmt.Errorf("public key is empty")
+	}
+
+	if strings.HasPrefix(publicKey, "-----BEGIN") {
+		block, rest := pem.Decode([]byte(publicKey))
+		if block == nil {
+			return nil, fmt.Errorf("failed to decode PEM public key")
+		}
+		if len(strings.TrimSpace(string(rest))) != 0 {
+			return nil, fmt.Errorf("PEM public key must contain exactly one block")
+		}
+
+		var key interface{}
+		var err error
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		default:
+			return nil, fmt.Errorf("unsupported PEM block type %s, private keys must not be used", block.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PEM public key : %w", err)
+		}
+		return ssh.NewPublicKey(key)
+	}
+
+	key, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenSSH public key : %w", err)
+	}
+	if len(strings.TrimSpace(string(rest))) != 0 {
+		return nil, fmt.Errorf("OpenSSH public key must contain exactly one key")
+	}
+	return key, nil
+}
+
+// normalizePublicKey returns the authorized_keys form of the public key without comment
+func normalizePublicKey(publicKey string) (string, error) {
+	key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))), nil
+}
+
+// publicKeyFingerprint returns the SHA256 fingerprint of the public key in the OpenSSH format (SHA256:...)
+func publicKeyFingerprint(publicKey string) (string, error) {
+	key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return ssh.FingerprintSHA256(key), nil
+}
+
+// privateKeyFingerprint returns the SHA256 fingerprint of the public part of the generated private key
+func privateKeyFingerprint(privateKey string) (string, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return "", err
+	}
+	return ssh.FingerprintSHA256(signer.PublicKey()), nil
+}
+
+func validatePublicKey(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Get attribute key
+	attr := path[len(path)-1].(cty.GetAttrStep)
+	attrKey := attr.Name
+
+	if _, err := parsePublicKey(v.(string)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Attribute %q has errors : %s", attrKey, err.Error()),
+			AttributePath: path,
+		})
+	}
+	return diags
+}
+
+// suppressPublicKeyDiff ignores differences of the key format and comment
+func suppressPublicKeyDiff(_, old, new string, _ *schema.ResourceData) bool {
+	oldKey, err := normalizePublicKey(old)
+	if err != nil {
+		return false
+	}
+	newKey, err := normalizePublicKey(new)
+	if err != nil {
+		return false
+	}
+	return oldKey == newKey
+}
@@ -0,0 +1,61 @@
+package keypair
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestPublicKeyFingerprint(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ssh.FingerprintSHA256(sshKey)
+
+	pkix, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeys := map[string]string{
+		"openssh":         string(ssh.MarshalAuthorizedKey(sshKey)),
+		"openssh comment": strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshKey))) + " user@host",
+		"pkix":            string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix})),
+		"pkcs1":           string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&privateKey.PublicKey)})),
+	}
+	for name, publicKey := range publicKeys {
+		fingerprint, err := publicKeyFingerprint(publicKey)
+		if err != nil {
+			t.Errorf("%s : %v", name, err)
+			continue
+		}
+		if fingerprint != expected {
+			t.Errorf("%s : expected %s but %s", name, expected, fingerprint)
+		}
+	}
+
+	if !suppressPublicKeyDiff("", publicKeys["openssh"], publicKeys["pkix"], nil) {
+		t.Error("same key in different formats should not make a diff")
+	}
+
+	privatePem := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}))
+	if _, err = parsePublicKey(privatePem); err == nil {
+		t.Error("private key should not be allowed")
+	}
+	if fingerprint, err := privateKeyFingerprint(privatePem); err != nil || fingerprint != expected {
+		t.Errorf("private key fingerprint : %s, %v", fingerprint, err)
+	}
+	if _, err = parsePublicKey("ssh-rsa invalid"); err == nil {
+		t.Error("invalid key should not be allowed")
+	}
+}
@@ -0,0 +1,343 @@
+package loadbalancer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/loadbalancer"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/loadbalancer2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// lbServerGroupMutexKV serializes the changes of the members of a server group, because the members are always replaced as a whole
+var lbServerGroupMutexKV = common.NewMutexKV()
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_lb_server_group_member", ResourceLbServerGroupMember())
+}
+
+func ResourceLbServerGroupMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLbServerGroupMemberCreate,
+		ReadContext:   resourceLbServerGroupMemberRead,
+		UpdateContext: resourceLbServerGroupMemberUpdate,
+		DeleteContext: resourceLbServerGroupMemberDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLbServerGroupMemberImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"lb_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Load-Balancer id",
+			},
+			"lb_server_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Load-Balancer server group id",
+			},
+			"object_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: ValidateLbServerGroupObjectType,
+				Description:      "Target object type. (INSTANCE, BAREMETAL, MANUAL)",
+			},
+			"object_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Target object id (VM server or BareMetal server). Required if the object_type is INSTANCE or BAREMETAL. Input resource should be in the same VPC.",
+			},
+			"object_ip_address": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: common.ValidateCidrIpv4,
+				Description:      "Target object ip. Required if the object_type is MANUAL.",
+			},
+			"object_port": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: common.ValidatePortRange,
+				Description:      "Target object port. (1 to 65535)",
+			},
+			"weight": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: ValidateLbServerGroupWeight,
+				Description:      "Balancing weight. This is used with when weighted algorithm is set. (1 to 256)",
+			},
+			"join_state": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"ENABLED", "DISABLED", "GRACEFUL_DISABLED"}, false)),
+				Description:      "Target service joining state. (ENABLED, DISABLED, GRACEFUL_DISABLED)",
+			},
+			"member_ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Ip address of the member",
+			},
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			objectType := d.Get("object_type").(string)
+			if objectType == "INSTANCE" || objectType == "BAREMETAL" {
+				if len(d.Get("object_id").(string)) < 1 {
+					return fmt.Errorf("if the object_type is \"INSTANCE\" or \"BAREMETAL\", an object_id is required")
+				}
+				if len(d.Get("object_ip_address").(string)) > 0 {
+					return fmt.Errorf("if the object_type is \"INSTANCE\" or \"BAREMETAL\", object_ip_address is not required")
+				}
+			} else if d.NewValueKnown("object_ip_address") && len(d.Get("object_ip_address").(string)) < 1 {
+				return fmt.Errorf("if the object_type is \"MANUAL\", an object_ip_address is required")
+			}
+			return nil
+		},
+		Description: "Provides a single member of a Load Balancer Server Group. Other members of the server group are not changed.",
+	}
+}
+
+// lbServerGroupMemberKey identifies a member by the target object and the port.
+// INSTANCE and BAREMETAL members are identified by the object id, and MANUAL members by the ip address.
+func lbServerGroupMemberKey(objectType string, objectId string, ipAddress string, port int32) string {
+	target := ipAddress
+	if objectType == "INSTANCE" || objectType == "BAREMETAL" {
+		target = objectId
+	}
+	return target + ":" + strconv.Itoa(int(port))
+}
+
+func getLbServerGroupMemberKeys(members []loadbalancer.LbServerGroupMember) map[string]bool {
+	keys := make(map[string]bool)
+	for _, member := range members {
+		keys[lbServerGroupMemberKey(member.ObjectType, member.ObjectId, member.ObjectIpAddress, member.ObjectPort)] = true
+	}
+	return keys
+}
+
+// convertLbServerGroupMembers converts the members of the server group into the update request form
+func convertLbServerGroupMembers(info loadbalancer2.LbServerGroupDetailResponse) []loadbalancer.LbServerGroupMember {
+	var members []loadbalancer.LbServerGroupMember
+	for _, svc := range info.LbServerGroupMembers {
+		members = append(members, loadbalancer.LbServerGroupMember{
+			JoinState:       svc.JoinState,
+			ObjectType:      svc.ObjectType,
+			ObjectId:        svc.ObjectId,
+			ObjectIpAddress: svc.MemberIpAddress,
+			ObjectPort:      svc.MemberPort,
+			Weight:          svc.MemberWeight,
+		})
+	}
+	return members
+}
+
+// convertLbServerGroupMonitor converts the monitor of the server group into the update request form
+func convertLbServerGroupMonitor(info loadbalancer2.LbServerGroupDetailResponse) loadbalancer.LbServerGroupMonitor {
+	return loadbalancer.LbServerGroupMonitor{
+		HttpMethod:        info.LbMonitor.HttpMethod,
+		HttpVersion:       info.LbMonitor.HttpVersion,
+		LbMonitorCount:    info.LbMonitor.LbMonitorCount,
+		LbMonitorInterval: info.LbMonitor.LbMonitorInterval,
+		LbMonitorPort:     info.LbMonitor.LbMonitorPort,
+		LbMonitorTimeout:  info.LbMonitor.LbMonitorTimeout,
+		LbMonitorUrl:      info.LbMonitor.LbMonitorUrl,
+		Protocol:          info.LbMonitor.Protocol,
+		RequestBody:       info.LbMonitor.RequestBody,
+		ResponseBody:      info.LbMonitor.ResponseBody,
+	}
+}
+
+// resolveLbServerGroupMemberIp fills the ip address of INSTANCE members from the virtual server
+func resolveLbServerGroupMemberIp(ctx context.Context, inst *client.Instance, members []loadbalancer.LbServerGroupMember) error {
+	for i, m := range members {
+		if m.ObjectType == "INSTANCE" && members[i].ObjectIpAddress == "" {
+			vsInfo, _, err := inst.Client.VirtualServer.GetVirtualServer(ctx, m.ObjectId)
+			if err != nil {
+				return err
+			}
+			members[i].ObjectIpAddress = vsInfo.Ip
+			if len(members[i].ObjectIpAddress) == 0 {
+				return fmt.Errorf("failed to retrieve ip information from VirtualServer.")
+			}
+		}
+	}
+	return nil
+}
+
+func expandLbServerGroupMemberAttachment(rd *schema.ResourceData) loadbalancer.LbServerGroupMember {
+	member := loadbalancer.LbServerGroupMember{
+		JoinState:  strings.ToUpper(rd.Get("join_state").(string)),
+		ObjectType: rd.Get("object_type").(string),
+		ObjectPort: int32(rd.Get("object_port").(int)),
+		Weight:     int32(rd.Get("weight").(int)),
+	}
+	if member.ObjectType == "INSTANCE" || member.ObjectType == "BAREMETAL" {
+		member.ObjectId = rd.Get("object_id").(string)
+	} else {
+		member.ObjectIpAddress = rd.Get("object_ip_address").(string)
+	}
+	return member
+}
+
+// applyLbServerGroupMemberAttachment replaces the member of the server group with the attachment, or removes it if remove is true
+func applyLbServerGroupMemberAttachment(ctx context.Context, rd *schema.ResourceData, meta interface{}, remove bool) error {
+	inst := meta.(*client.Instance)
+
+	loadBalancerId := rd.Get("lb_id").(string)
+	lbServerGroupId := rd.Get("lb_server_group_id").(string)
+	attachment := expandLbServerGroupMemberAttachment(rd)
+	attachmentKey := lbServerGroupMemberKey(attachment.ObjectType, attachment.ObjectId, attachment.ObjectIpAddress, attachment.ObjectPort)
+
+	lbServerGroupMutexKV.Lock(lbServerGroupId)
+	defer lbServerGroupMutexKV.Unlock(lbServerGroupId)
+
+	err := waitForLbServerGroupStatus(ctx, inst.Client, lbServerGroupId, loadBalancerId, []string{}, []string{"ACTIVE"}, true)
+	if err != nil {
+		return err
+	}
+
+	info, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, lbServerGroupId, loadBalancerId)
+	if err != nil {
+		return err
+	}
+
+	var members []loadbalancer.LbServerGroupMember
+	found := false
+	for _, member := range convertLbServerGroupMembers(info) {
+		if lbServerGroupMemberKey(member.ObjectType, member.ObjectId, member.ObjectIpAddress, member.ObjectPort) != attachmentKey {
+			members = append(members, member)
+			continue
+		}
+		found = true
+	}
+
+	if remove {
+		if !found {
+			return nil
+		}
+	} else {
+		if found && rd.IsNewResource() {
+			return fmt.Errorf("member %s already exists in server group %s. import it with %s/%s/%s", attachmentKey, lbServerGroupId, loadBalancerId, lbServerGroupId, attachmentKey)
+		}
+		members = append(members, attachment)
+	}
+
+	err = resolveLbServerGroupMemberIp(ctx, inst, members)
+	if err != nil {
+		return err
+	}
+
+	monitor := convertLbServerGroupMonitor(info)
+	if _, err := inst.Client.LoadBalancer.UpdateLbServerGroup(ctx, true, info.LbServerGroupAlgorithm, lbServerGroupId, loadBalancerId, &monitor, members); err != nil {
+		return err
+	}
+
+	return waitForLbServerGroupStatus(ctx, inst.Client, lbServerGroupId, loadBalancerId, []string{}, []string{"ACTIVE"}, true)
+}
+
+func resourceLbServerGroupMemberCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := applyLbServerGroupMemberAttachment(ctx, rd, meta, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	member := expandLbServerGroupMemberAttachment(rd)
+	rd.SetId(rd.Get("lb_server_group_id").(string) + "/" + lbServerGroupMemberKey(member.ObjectType, member.ObjectId, member.ObjectIpAddress, member.ObjectPort))
+
+	return resourceLbServerGroupMemberRead(ctx, rd, meta)
+}
+
+func resourceLbServerGroupMemberRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	info, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, rd.Get("lb_server_group_id").(string), rd.Get("lb_id").(string))
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	attachment := expandLbServerGroupMemberAttachment(rd)
+	attachmentKey := lbServerGroupMemberKey(attachment.ObjectType, attachment.ObjectId, attachment.ObjectIpAddress, attachment.ObjectPort)
+
+	for _, svc := range info.LbServerGroupMembers {
+		if lbServerGroupMemberKey(svc.ObjectType, svc.ObjectId, svc.MemberIpAddress, svc.MemberPort) != attachmentKey {
+			continue
+		}
+		rd.Set("join_state", svc.JoinState)
+		rd.Set("object_port", svc.MemberPort)
+		rd.Set("weight", svc.MemberWeight)
+		rd.Set("member_ip_address", svc.MemberIpAddress)
+		return nil
+	}
+
+	rd.SetId("")
+	return nil
+}
+
+func resourceLbServerGroupMemberUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if rd.HasChanges("weight", "join_state") {
+		err := applyLbServerGroupMemberAttachment(ctx, rd, meta, false)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceLbServerGroupMemberRead(ctx, rd, meta)
+}
+
+func resourceLbServerGroupMemberDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := applyLbServerGroupMemberAttachment(ctx, rd, meta, true)
+	if err != nil && !common.IsDeleted(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceLbServerGroupMemberImport imports with "<lb_id>/<lb_server_group_id>/<object_id or object_ip_address>:<object_port>" format
+func resourceLbServerGroupMemberImport(ctx context.Context, rd *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	inst := meta.(*client.Instance)
+
+	ids := strings.Split(rd.Id(), "/")
+	if len(ids) != 3 || len(ids[0]) == 0 || len(ids[1]) == 0 || len(ids[2]) == 0 {
+		return nil, fmt.Errorf("invalid import id %q. expected format is <lb_id>/<lb_server_group_id>/<object_id or object_ip_address>:<object_port>", rd.Id())
+	}
+
+	info, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, ids[1], ids[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, svc := range info.LbServerGroupMembers {
+		if lbServerGroupMemberKey(svc.ObjectType, svc.ObjectId, svc.MemberIpAddress, svc.MemberPort) != ids[2] {
+			continue
+		}
+		rd.Set("lb_id", ids[0])
+		rd.Set("lb_server_group_id", ids[1])
+		rd.Set("object_type", svc.ObjectType)
+		if svc.ObjectType == "INSTANCE" || svc.ObjectType == "BAREMETAL" {
+			rd.Set("object_id", svc.ObjectId)
+		} else {
+			rd.Set("object_ip_address", svc.MemberIpAddress)
+		}
+		rd.Set("object_port", svc.MemberPort)
+		rd.SetId(ids[1] + "/" + ids[2])
+		return []*schema.ResourceData{rd}, nil
+	}
+
+	return nil, fmt.Errorf("member %s not found in server group %s", ids[2], ids[1])
+}
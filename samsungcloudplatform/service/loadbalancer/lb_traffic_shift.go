@@ -0,0 +1,411 @@
+package loadbalancer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/loadbalancer"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_lb_traffic_shift", ResourceLbTrafficShift())
+}
+
+func ResourceLbTrafficShift() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLbTrafficShiftCreate,
+		ReadContext:   resourceLbTrafficShiftRead,
+		UpdateContext: resourceLbTrafficShiftUpdate,
+		DeleteContext: resourceLbTrafficShiftDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"lb_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Load-Balancer id",
+			},
+			"blue_server_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Server group which serves the traffic. (lb_server_group_id of the lb_rules) The algorithm must be WEIGHTED_ROUND_ROBIN or WEIGHTED_LEAST_CONNECTION.",
+			},
+			"green_server_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Server group of the new members. The members are added to the blue server group during the shift.",
+			},
+			"green_weight": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 100)),
+				Description:      "Percentage of the traffic to the green members. (0 to 100)",
+			},
+			"step_weight": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 100)),
+				Description:      "Percentage of the traffic shifted in a step. (1 to 100)",
+			},
+			"step_interval_sec": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          60,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 3600)),
+				Description:      "Pause after each step before the health check. (0 to 3600)",
+			},
+			"rollback_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If true, the weights are rolled back to the green_weight before the shift when a step fails",
+			},
+			"blue_member_weights": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Original weights of the blue members by <object_id or ip address>:<port>, restored when green_weight is 0",
+			},
+		},
+		Description: "Provides a weighted traffic shift from the members of a blue server group to the members of a green server group.",
+	}
+}
+
+// applyLbTrafficShiftWeight sets the member weights of the blue server group for the green weight
+func applyLbTrafficShiftWeight(ctx context.Context, rd *schema.ResourceData, inst *client.Instance, greenPercent int) error {
+	loadBalancerId := rd.Get("lb_id").(string)
+	blueServerGroupId := rd.Get("blue_server_group_id").(string)
+	greenServerGroupId := rd.Get("green_server_group_id").(string)
+	blueMemberWeights := rd.Get("blue_member_weights").(map[string]interface{})
+
+	lbServerGroupMutexKV.Lock(blueServerGroupId)
+	defer lbServerGroupMutexKV.Unlock(blueServerGroupId)
+
+	err := waitForLbServerGroupStatus(ctx, inst.Client, blueServerGroupId, loadBalancerId, []string{}, []string{"ACTIVE"}, true)
+	if err != nil {
+		return err
+	}
+
+	blueInfo, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, blueServerGroupId, loadBalancerId)
+	if err != nil {
+		return err
+	}
+	greenInfo, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, greenServerGroupId, loadBalancerId)
+	if err != nil {
+		return err
+	}
+
+	greenMembers := convertLbServerGroupMembers(greenInfo)
+	greenMemberKeys := getLbServerGroupMemberKeys(greenMembers)
+
+	var blueMembers []loadbalancer.LbServerGroupMember
+	for _, member := range convertLbServerGroupMembers(blueInfo) {
+		if !greenMemberKeys[lbServerGroupMemberKey(member.ObjectType, member.ObjectId, member.ObjectIpAddress, member.ObjectPort)] {
+			blueMembers = append(blueMembers, member)
+		}
+	}
+	if len(blueMembers) == 0 || len(greenMembers) == 0 {
+		return fmt.Errorf("both blue and green server groups must have at least one member")
+	}
+
+	blueWeight, greenWeight := trafficShiftWeights(len(blueMembers), len(greenMembers), greenPercent)
+
+	var members []loadbalancer.LbServerGroupMember
+	for _, member := range blueMembers {
+		// members disabled by the user are not changed
+		if member.JoinState == "DISABLED" {
+			members = append(members, member)
+			continue
+		}
+		switch greenPercent {
+		case 0:
+			member.JoinState = "ENABLED"
+			if weight, ok := blueMemberWeights[lbServerGroupMemberKey(member.ObjectType, member.ObjectId, member.ObjectIpAddress, member.ObjectPort)]; ok {
+				member.Weight = int32(weight.(int))
+			}
+		case 100:
+			member.JoinState = "GRACEFUL_DISABLED"
+		default:
+			member.JoinState = "ENABLED"
+			member.Weight = blueWeight
+		}
+		members = append(members, member)
+	}
+	if greenPercent > 0 {
+		for _, member := range greenMembers {
+			member.JoinState = "ENABLED"
+			member.Weight = greenWeight
+			if greenPercent == 100 {
+				member.Weight = 1
+			}
+			members = append(members, member)
+		}
+	}
+
+	err = resolveLbServerGroupMemberIp(ctx, inst, members)
+	if err != nil {
+		return err
+	}
+
+	monitor := convertLbServerGroupMonitor(blueInfo)
+	if _, err := inst.Client.LoadBalancer.UpdateLbServerGroup(ctx, true, blueInfo.LbServerGroupAlgorithm, blueServerGroupId, loadBalancerId, &monitor, members); err != nil {
+		return err
+	}
+
+	return waitForLbServerGroupStatus(ctx, inst.Client, blueServerGroupId, loadBalancerId, []string{}, []string{"ACTIVE"}, true)
+}
+
+// checkLbTrafficShiftHealth checks that both server groups are active, and the green members are enabled in the blue server group
+func checkLbTrafficShiftHealth(ctx context.Context, rd *schema.ResourceData, inst *client.Instance, greenPercent int) error {
+	loadBalancerId := rd.Get("lb_id").(string)
+
+	blueInfo, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, rd.Get("blue_server_group_id").(string), loadBalancerId)
+	if err != nil {
+		return err
+	}
+	if blueInfo.LbServerGroupState != common.ActiveState {
+		return fmt.Errorf("blue server group is %s", blueInfo.LbServerGroupState)
+	}
+
+	greenInfo, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, rd.Get("green_server_group_id").(string), loadBalancerId)
+	if err != nil {
+		return err
+	}
+	if greenInfo.LbServerGroupState != common.ActiveState {
+		return fmt.Errorf("green server group is %s", greenInfo.LbServerGroupState)
+	}
+
+	if greenPercent == 0 {
+		return nil
+	}
+	joinStates := make(map[string]string)
+	for _, svc := range blueInfo.LbServerGroupMembers {
+		joinStates[lbServerGroupMemberKey(svc.ObjectType, svc.ObjectId, svc.MemberIpAddress, svc.MemberPort)] = svc.JoinState
+	}
+	for key := range getLbServerGroupMemberKeys(convertLbServerGroupMembers(greenInfo)) {
+		if joinStates[key] != "ENABLED" {
+			return fmt.Errorf("green member %s is not enabled in the blue server group", key)
+		}
+	}
+	return nil
+}
+
+// shiftLbTraffic shifts the green weight step by step, and rolls it back if a step fails
+func shiftLbTraffic(ctx context.Context, rd *schema.ResourceData, inst *client.Instance, from int, to int) error {
+	stepInterval := time.Duration(rd.Get("step_interval_sec").(int)) * time.Second
+
+	current := from
+	for _, greenPercent := range trafficShiftSteps(from, to, rd.Get("step_weight").(int)) {
+		log.Printf("[INFO] Shifting %d%% of traffic to the green server group", greenPercent)
+
+		err := applyLbTrafficShiftWeight(ctx, rd, inst, greenPercent)
+		if err == nil {
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+			case <-time.After(stepInterval):
+				err = checkLbTrafficShiftHealth(ctx, rd, inst, greenPercent)
+			}
+		}
+		if err != nil {
+			if !rd.Get("rollback_on_failure").(bool) {
+				rd.Set("green_weight", greenPercent)
+				return fmt.Errorf("failed to shift %d%% of traffic : %w", greenPercent, err)
+			}
+			log.Printf("[WARN] Rolling back traffic shift to %d%% : %s", from, err.Error())
+			if rollbackErr := applyLbTrafficShiftWeight(ctx, rd, inst, from); rollbackErr != nil {
+				rd.Set("green_weight", greenPercent)
+				return fmt.Errorf("failed to shift %d%% of traffic : %s, and failed to roll back to %d%% : %w", greenPercent, err.Error(), from, rollbackErr)
+			}
+			rd.Set("green_weight", from)
+			return fmt.Errorf("failed to shift %d%% of traffic, and rolled back to %d%% : %w", greenPercent, from, err)
+		}
+		current = greenPercent
+	}
+
+	rd.Set("green_weight", current)
+	return nil
+}
+
+func resourceLbTrafficShiftCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	loadBalancerId := rd.Get("lb_id").(string)
+	blueServerGroupId := rd.Get("blue_server_group_id").(string)
+	greenServerGroupId := rd.Get("green_server_group_id").(string)
+	if blueServerGroupId == greenServerGroupId {
+		return diag.Errorf("blue_server_group_id and green_server_group_id must be different")
+	}
+
+	blueInfo, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, blueServerGroupId, loadBalancerId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if blueInfo.LbServerGroupAlgorithm != "WEIGHTED_ROUND_ROBIN" && blueInfo.LbServerGroupAlgorithm != "WEIGHTED_LEAST_CONNECTION" {
+		return diag.Errorf("algorithm of the blue server group must be WEIGHTED_ROUND_ROBIN or WEIGHTED_LEAST_CONNECTION : %s", blueInfo.LbServerGroupAlgorithm)
+	}
+	greenInfo, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, greenServerGroupId, loadBalancerId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// remember the original weights of the blue members to restore them
+	greenMemberKeys := getLbServerGroupMemberKeys(convertLbServerGroupMembers(greenInfo))
+	blueMemberWeights := make(map[string]interface{})
+	for _, member := range convertLbServerGroupMembers(blueInfo) {
+		key := lbServerGroupMemberKey(member.ObjectType, member.ObjectId, member.ObjectIpAddress, member.ObjectPort)
+		if !greenMemberKeys[key] {
+			blueMemberWeights[key] = int(member.Weight)
+		}
+	}
+	rd.Set("blue_member_weights", blueMemberWeights)
+	rd.SetId(blueServerGroupId + "/" + greenServerGroupId)
+
+	err = shiftLbTraffic(ctx, rd, inst, 0, rd.Get("green_weight").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceLbTrafficShiftRead(ctx, rd, meta)
+}
+
+func resourceLbTrafficShiftRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	loadBalancerId := rd.Get("lb_id").(string)
+	blueInfo, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, rd.Get("blue_server_group_id").(string), loadBalancerId)
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	greenInfo, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, rd.Get("green_server_group_id").(string), loadBalancerId)
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// green members removed from the blue server group outside of the resource are shifted again on the next apply
+	if rd.Get("green_weight").(int) > 0 {
+		blueMemberKeys := make(map[string]bool)
+		for _, svc := range blueInfo.LbServerGroupMembers {
+			blueMemberKeys[lbServerGroupMemberKey(svc.ObjectType, svc.ObjectId, svc.MemberIpAddress, svc.MemberPort)] = true
+		}
+		for key := range getLbServerGroupMemberKeys(convertLbServerGroupMembers(greenInfo)) {
+			if !blueMemberKeys[key] {
+				rd.Set("green_weight", 0)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceLbTrafficShiftUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	if rd.HasChange("green_weight") {
+		from, to := rd.GetChange("green_weight")
+		err := shiftLbTraffic(ctx, rd, inst, from.(int), to.(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceLbTrafficShiftRead(ctx, rd, meta)
+}
+
+// restoreLbTrafficShiftBlueWeights removes the green members from the blue server group, and restores the original weights.
+// It does not require the green server group, so the weights are restored even if the green server group is deleted or empty.
+func restoreLbTrafficShiftBlueWeights(ctx context.Context, rd *schema.ResourceData, inst *client.Instance) error {
+	loadBalancerId := rd.Get("lb_id").(string)
+	blueServerGroupId := rd.Get("blue_server_group_id").(string)
+	blueMemberWeights := rd.Get("blue_member_weights").(map[string]interface{})
+
+	lbServerGroupMutexKV.Lock(blueServerGroupId)
+	defer lbServerGroupMutexKV.Unlock(blueServerGroupId)
+
+	err := waitForLbServerGroupStatus(ctx, inst.Client, blueServerGroupId, loadBalancerId, []string{}, []string{"ACTIVE"}, true)
+	if err != nil {
+		return err
+	}
+
+	blueInfo, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, blueServerGroupId, loadBalancerId)
+	if err != nil {
+		return err
+	}
+
+	// if the green server group is deleted or empty, the members which were not in the blue server group on create are the green members
+	var greenMemberKeys map[string]bool
+	greenInfo, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, rd.Get("green_server_group_id").(string), loadBalancerId)
+	if err == nil {
+		greenMemberKeys = getLbServerGroupMemberKeys(convertLbServerGroupMembers(greenInfo))
+	} else if !common.IsDeleted(err) {
+		return err
+	}
+	if len(greenMemberKeys) == 0 {
+		greenMemberKeys = nil
+	}
+
+	var members []loadbalancer.LbServerGroupMember
+	for _, member := range convertLbServerGroupMembers(blueInfo) {
+		key := lbServerGroupMemberKey(member.ObjectType, member.ObjectId, member.ObjectIpAddress, member.ObjectPort)
+		weight, isBlue := blueMemberWeights[key]
+		if (greenMemberKeys != nil && greenMemberKeys[key]) || (greenMemberKeys == nil && !isBlue) {
+			continue
+		}
+		// members disabled by the user are not changed
+		if member.JoinState != "DISABLED" {
+			member.JoinState = "ENABLED"
+			if isBlue {
+				member.Weight = int32(weight.(int))
+			}
+		}
+		members = append(members, member)
+	}
+
+	err = resolveLbServerGroupMemberIp(ctx, inst, members)
+	if err != nil {
+		return err
+	}
+
+	monitor := convertLbServerGroupMonitor(blueInfo)
+	if _, err := inst.Client.LoadBalancer.UpdateLbServerGroup(ctx, true, blueInfo.LbServerGroupAlgorithm, blueServerGroupId, loadBalancerId, &monitor, members); err != nil {
+		return err
+	}
+
+	return waitForLbServerGroupStatus(ctx, inst.Client, blueServerGroupId, loadBalancerId, []string{}, []string{"ACTIVE"}, true)
+}
+
+// resourceLbTrafficShiftDelete removes the green members from the blue server group, and restores the original weights
+func resourceLbTrafficShiftDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	err := restoreLbTrafficShiftBlueWeights(ctx, rd, inst)
+	if err != nil && !common.IsDeleted(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
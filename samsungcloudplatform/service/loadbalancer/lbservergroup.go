@@ -26,7 +26,7 @@ func ResourceLbServerGroup() *schema.Resource {
 		UpdateContext: resourceLbServerGroupUpdate,
 		DeleteContext: resourceLbServerGroupDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceLbServerGroupImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"lb_id": {
@@ -101,6 +101,12 @@ func ResourceLbServerGroup() *schema.Resource {
 					},
 				},
 			},
+			"ignore_external_members": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, members which are not in server_group_member (e.g. attached by samsungcloudplatform_lb_server_group_member) are neither read nor removed on update.",
+			},
 			"monitor_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -341,8 +347,22 @@ func resourceLbServerGroupRead(ctx context.Context, rd *schema.ResourceData, met
 		return diag.FromErr(err)
 	}
 
+	// members of the prior state are owned by the server group.
+	// the flag is not in the state on import, so every member is read then
+	var ownedMemberKeys map[string]bool
+	if rd.Get("ignore_external_members").(bool) {
+		ownedMembers, err := expandMembers(rd)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ownedMemberKeys = getLbServerGroupMemberKeys(ownedMembers)
+	}
+
 	svgMembers := common.HclSetObject{}
 	for _, svc := range info.LbServerGroupMembers {
+		if ownedMemberKeys != nil && !ownedMemberKeys[lbServerGroupMemberKey(svc.ObjectType, svc.ObjectId, svc.MemberIpAddress, svc.MemberPort)] {
+			continue
+		}
 		member := common.HclKeyValueObject{
 			"join_state":  svc.JoinState,
 			"object_type": svc.ObjectType,
@@ -393,24 +413,26 @@ func resourceLbServerGroupUpdate(ctx context.Context, rd *schema.ResourceData, m
 		"monitor_http_request_body",
 		"monitor_http_response_body") {
 
+		lbServerGroupMutexKV.Lock(rd.Id())
+		defer lbServerGroupMutexKV.Unlock(rd.Id())
+
 		members, err := expandMembers(rd)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
-		for i, m := range members {
-			if m.ObjectType == "INSTANCE" && members[i].ObjectIpAddress == "" {
-				vsInfo, _, err := inst.Client.VirtualServer.GetVirtualServer(ctx, m.ObjectId)
-				if err != nil {
-					return diag.FromErr(err)
-				}
-				members[i].ObjectIpAddress = vsInfo.Ip
-				if len(members[i].ObjectIpAddress) == 0 {
-					return diag.Errorf("failed to retrieve ip information from VirtualServer.")
-				}
+		if rd.Get("ignore_external_members").(bool) {
+			members, err = mergeExternalLbServerGroupMembers(ctx, inst, rd, members)
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 
+		err = resolveLbServerGroupMemberIp(ctx, inst, members)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
 		monitor := loadbalancer.LbServerGroupMonitor{
 			HttpMethod:        rd.Get("monitor_http_method").(string),
 			HttpVersion:       rd.Get("monitor_http_version").(string),
@@ -454,6 +476,42 @@ func resourceLbServerGroupUpdate(ctx context.Context, rd *schema.ResourceData, m
 	return resourceLbServerGroupRead(ctx, rd, meta)
 }
 
+// mergeExternalLbServerGroupMembers appends the current members which are neither in the prior state nor in server_group_member
+func mergeExternalLbServerGroupMembers(ctx context.Context, inst *client.Instance, rd *schema.ResourceData, members []loadbalancer.LbServerGroupMember) ([]loadbalancer.LbServerGroupMember, error) {
+	info, _, err := inst.Client.LoadBalancer.GetLbServerGroup(ctx, rd.Id(), rd.Get("lb_id").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	oldMemberList, _ := rd.GetChange("server_group_member")
+	oldMembers, err := expandServerGroupMember(oldMemberList.([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	// members of the prior state are owned only if they were read with the flag.
+	// after import or when the flag is turned on, the prior state has every member, and the members not in the config are kept
+	ownedMemberKeys := make(map[string]bool)
+	if oldIgnore, _ := rd.GetChange("ignore_external_members"); oldIgnore.(bool) {
+		ownedMemberKeys = getLbServerGroupMemberKeys(oldMembers)
+	}
+	for key := range getLbServerGroupMemberKeys(members) {
+		ownedMemberKeys[key] = true
+	}
+	for _, member := range convertLbServerGroupMembers(info) {
+		if !ownedMemberKeys[lbServerGroupMemberKey(member.ObjectType, member.ObjectId, member.ObjectIpAddress, member.ObjectPort)] {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// resourceLbServerGroupImport reads every member of the server group, regardless of ignore_external_members in the config
+func resourceLbServerGroupImport(ctx context.Context, rd *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	rd.Set("ignore_external_members", false)
+	return []*schema.ResourceData{rd}, nil
+}
+
 func resourceLbServerGroupDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	inst := meta.(*client.Instance)
@@ -126,7 +126,7 @@ func ResourceLbService() *schema.Resource {
 			"client_certificate_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "SSL client certification id.",
+				Description: "SSL client certification id. (id of samsungcloudplatform_certificate)",
 			},
 			"client_ssl_security_level": {
 				Type:        schema.TypeString,
@@ -136,7 +136,7 @@ func ResourceLbService() *schema.Resource {
 			"server_certificate_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "SSL server certification id.",
+				Description: "SSL server certification id. (id of samsungcloudplatform_certificate)",
 			},
 			"server_ssl_security_level": {
 				Type:        schema.TypeString,
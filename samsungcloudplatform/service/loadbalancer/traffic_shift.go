@@ -0,0 +1,52 @@
+package loadbalancer
+
+import "math"
+
+// lbServerGroupMaxWeight is the maximum weight of a server group member
+const lbServerGroupMaxWeight = 256
+
+// trafficShiftSteps returns the green weights to apply in order, from the weight after from to the weight to
+func trafficShiftSteps(from int, to int, step int) []int {
+	if step < 1 {
+		step = 1
+	}
+	var steps []int
+	for current := from; current != to; {
+		if current < to {
+			current = int(math.Min(float64(current+step), float64(to)))
+		} else {
+			current = int(math.Max(float64(current-step), float64(to)))
+		}
+		steps = append(steps, current)
+	}
+	return steps
+}
+
+// trafficShiftWeights returns the member weights of blue and green members,
+// so that green members get the greenPercent of the traffic in total.
+// It is only for 0 < greenPercent < 100, because a weight can not be 0.
+func trafficShiftWeights(blueCount int, greenCount int, greenPercent int) (int32, int32) {
+	blueWeight := (100 - greenPercent) * greenCount
+	greenWeight := greenPercent * blueCount
+
+	divisor := gcd(blueWeight, greenWeight)
+	blueWeight /= divisor
+	greenWeight /= divisor
+
+	if maxWeight := int(math.Max(float64(blueWeight), float64(greenWeight))); maxWeight > lbServerGroupMaxWeight {
+		scale := float64(lbServerGroupMaxWeight) / float64(maxWeight)
+		blueWeight = int(math.Max(1, math.Round(float64(blueWeight)*scale)))
+		greenWeight = int(math.Max(1, math.Round(float64(greenWeight)*scale)))
+	}
+	return int32(blueWeight), int32(greenWeight)
+}
+
+func gcd(a int, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a == 0 {
+		return 1
+	}
+	return a
+}
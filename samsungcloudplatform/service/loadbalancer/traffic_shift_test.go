@@ -0,0 +1,48 @@
+package loadbalancer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrafficShiftSteps(t *testing.T) {
+	tests := []struct {
+		from, to, step int
+		expected       []int
+	}{
+		{0, 30, 10, []int{10, 20, 30}},
+		{0, 25, 10, []int{10, 20, 25}},
+		{50, 20, 20, []int{30, 20}},
+		{40, 40, 10, nil},
+		{0, 3, 0, []int{1, 2, 3}},
+		{0, 100, 100, []int{100}},
+	}
+	for _, test := range tests {
+		if steps := trafficShiftSteps(test.from, test.to, test.step); !reflect.DeepEqual(steps, test.expected) {
+			t.Errorf("trafficShiftSteps(%d, %d, %d) = %v, expected %v", test.from, test.to, test.step, steps, test.expected)
+		}
+	}
+}
+
+func TestTrafficShiftWeights(t *testing.T) {
+	tests := []struct {
+		blueCount, greenCount, greenPercent int
+		blueWeight, greenWeight             int32
+	}{
+		{2, 2, 10, 9, 1},
+		{2, 2, 50, 1, 1},
+		{3, 1, 25, 1, 1},
+		{1, 1, 1, 99, 1},
+		{1, 3, 1, 256, 1},
+		{4, 1, 99, 1, 256},
+	}
+	for _, test := range tests {
+		blueWeight, greenWeight := trafficShiftWeights(test.blueCount, test.greenCount, test.greenPercent)
+		if blueWeight != test.blueWeight || greenWeight != test.greenWeight {
+			t.Errorf("trafficShiftWeights(%d, %d, %d) = (%d, %d), expected (%d, %d)", test.blueCount, test.greenCount, test.greenPercent, blueWeight, greenWeight, test.blueWeight, test.greenWeight)
+		}
+		if blueWeight < 1 || greenWeight < 1 || blueWeight > lbServerGroupMaxWeight || greenWeight > lbServerGroupMaxWeight {
+			t.Errorf("trafficShiftWeights(%d, %d, %d) = (%d, %d) is out of range", test.blueCount, test.greenCount, test.greenPercent, blueWeight, greenWeight)
+		}
+	}
+}
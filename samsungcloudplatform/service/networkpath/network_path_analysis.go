@@ -0,0 +1,580 @@
+package networkpath
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/peering"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/routing"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	securitygroup2 "github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/security-group2"
+	transitgateway2 "github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/transit-gateway2"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func init() {
+	samsungcloudplatform.RegisterDataSource("samsungcloudplatform_network_path_analysis", DatasourceNetworkPathAnalysis())
+}
+
+const (
+	hopTypeSecurityGroupEgress  string = "SECURITY_GROUP_EGRESS"
+	hopTypeSecurityGroupIngress string = "SECURITY_GROUP_INGRESS"
+	hopTypeRoute                string = "ROUTE"
+	hopTypePeering              string = "VPC_PEERING"
+	hopTypeTransitGateway       string = "TRANSIT_GATEWAY"
+	hopTypeFirewall             string = "FIREWALL"
+)
+
+func DatasourceNetworkPathAnalysis() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceNetworkPathAnalysisRead,
+		Schema: map[string]*schema.Schema{
+			"source_virtual_server_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Source virtual server id. Either source_virtual_server_id or source_ip must be specified.",
+			},
+			"source_ip": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: common.ValidateIpv4WithEmptyValue,
+				Description:      "Source ip address. Used as is when source_virtual_server_id is not specified.",
+			},
+			"destination_virtual_server_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Destination virtual server id. Either destination_virtual_server_id or destination_ip must be specified.",
+			},
+			"destination_ip": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: common.ValidateIpv4WithEmptyValue,
+				Description:      "Destination ip address. Used as is when destination_virtual_server_id is not specified.",
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Protocol of the traffic. (TCP, UDP, ICMP)",
+				ValidateFunc: validation.StringInSlice([]string{ProtocolTcp, ProtocolUdp, ProtocolIcmp}, false),
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				Description:  "Destination port of the traffic. Required for TCP and UDP.",
+				ValidateFunc: validation.IntBetween(-1, 65535),
+			},
+			"allowed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the traffic is allowed through every hop",
+			},
+			"hops": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Evaluated hops in order. Evaluation stops at the first hop which denies the traffic.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hop_type":     {Type: schema.TypeString, Computed: true, Description: "Hop type (SECURITY_GROUP_EGRESS, ROUTE, VPC_PEERING, TRANSIT_GATEWAY, FIREWALL, SECURITY_GROUP_INGRESS)"},
+						"resource_id":  {Type: schema.TypeString, Computed: true, Description: "Evaluated resource id"},
+						"rule_id":      {Type: schema.TypeString, Computed: true, Description: "Rule id which decided the action. Empty when decided by default policy."},
+						"matched_cidr": {Type: schema.TypeString, Computed: true, Description: "Cidr of the rule which matched the traffic"},
+						"action":       {Type: schema.TypeString, Computed: true, Description: "Decided action (ALLOW, DENY)"},
+						"reason":       {Type: schema.TypeString, Computed: true, Description: "Reason of the decision"},
+					},
+				},
+			},
+		},
+		Description: "Analyzes reachability between two endpoints by evaluating security group, routing, peering, transit gateway and firewall configurations.",
+	}
+}
+
+type pathEndpoint struct {
+	VirtualServerId  string
+	Ip               net.IP
+	VpcId            string
+	SecurityGroupIds []string
+}
+
+type pathHop struct {
+	HopType     string
+	ResourceId  string
+	RuleId      string
+	MatchedCidr string
+	Action      string
+	Reason      string
+}
+
+func (h pathHop) toHcl() common.HclKeyValueObject {
+	return common.HclKeyValueObject{
+		"hop_type":     h.HopType,
+		"resource_id":  h.ResourceId,
+		"rule_id":      h.RuleId,
+		"matched_cidr": h.MatchedCidr,
+		"action":       h.Action,
+		"reason":       h.Reason,
+	}
+}
+
+type pathAnalyzer struct {
+	inst        *client.Instance
+	source      pathEndpoint
+	destination pathEndpoint
+	protocol    string
+	port        int
+	hops        []pathHop
+}
+
+func datasourceNetworkPathAnalysisRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	protocol := rd.Get("protocol").(string)
+	port := rd.Get("port").(int)
+	if protocol != ProtocolIcmp && port < 0 {
+		return diag.Errorf("port must be specified for %s protocol", protocol)
+	}
+
+	source, err := resolvePathEndpoint(ctx, inst, rd.Get("source_virtual_server_id").(string), rd.Get("source_ip").(string))
+	if err != nil {
+		return diag.Errorf("failed to resolve source : %s", err)
+	}
+	destination, err := resolvePathEndpoint(ctx, inst, rd.Get("destination_virtual_server_id").(string), rd.Get("destination_ip").(string))
+	if err != nil {
+		return diag.Errorf("failed to resolve destination : %s", err)
+	}
+
+	analyzer := &pathAnalyzer{
+		inst:        inst,
+		source:      source,
+		destination: destination,
+		protocol:    protocol,
+		port:        port,
+	}
+
+	allowed, err := analyzer.analyze(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hops := common.HclListObject{}
+	for _, hop := range analyzer.hops {
+		hops = append(hops, hop.toHcl())
+	}
+
+	rd.SetId(common.GenerateHash([]string{source.Ip.String(), destination.Ip.String(), protocol, strconv.Itoa(port)}))
+	rd.Set("source_ip", source.Ip.String())
+	rd.Set("destination_ip", destination.Ip.String())
+	rd.Set("allowed", allowed)
+	rd.Set("hops", hops)
+
+	return nil
+}
+
+func resolvePathEndpoint(ctx context.Context, inst *client.Instance, virtualServerId string, ipAddress string) (pathEndpoint, error) {
+	if len(virtualServerId) == 0 {
+		if len(ipAddress) == 0 {
+			return pathEndpoint{}, fmt.Errorf("either virtual server id or ip address must be specified")
+		}
+		return pathEndpoint{Ip: net.ParseIP(ipAddress)}, nil
+	}
+
+	virtualServerInfo, _, err := inst.Client.VirtualServer.GetVirtualServer(ctx, virtualServerId)
+	if err != nil {
+		return pathEndpoint{}, err
+	}
+
+	endpoint := pathEndpoint{
+		VirtualServerId: virtualServerId,
+		Ip:              net.ParseIP(virtualServerInfo.Ip),
+		VpcId:           virtualServerInfo.VpcId,
+	}
+	for _, sg := range virtualServerInfo.SecurityGroupIds {
+		endpoint.SecurityGroupIds = append(endpoint.SecurityGroupIds, sg.SecurityGroupId)
+	}
+
+	if len(ipAddress) != 0 {
+		// The given ip must belong to one of the network interfaces of the virtual server
+		nicInfo, err := inst.Client.VirtualServer.GetNicList(ctx, virtualServerId)
+		if err != nil {
+			return pathEndpoint{}, err
+		}
+		found := false
+		for _, nic := range nicInfo.Contents {
+			if nic.Ip == ipAddress {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return pathEndpoint{}, fmt.Errorf("ip address %s is not assigned to virtual server %s", ipAddress, virtualServerId)
+		}
+		endpoint.Ip = net.ParseIP(ipAddress)
+	}
+
+	if endpoint.Ip == nil {
+		return pathEndpoint{}, fmt.Errorf("ip address of virtual server %s not found", virtualServerId)
+	}
+	return endpoint, nil
+}
+
+func (a *pathAnalyzer) addHop(hop pathHop) bool {
+	a.hops = append(a.hops, hop)
+	return hop.Action == HopActionAllow
+}
+
+func (a *pathAnalyzer) analyze(ctx context.Context) (bool, error) {
+	// 1. Egress of the source security groups
+	if len(a.source.VirtualServerId) != 0 {
+		hop, err := a.evaluateSecurityGroups(ctx, a.source.SecurityGroupIds, "OUT", a.destination.Ip, hopTypeSecurityGroupEgress)
+		if err != nil {
+			return false, err
+		}
+		if !a.addHop(hop) {
+			return false, nil
+		}
+	}
+
+	// 2. Routing between the source and the destination networks
+	if len(a.source.VpcId) != 0 {
+		if a.source.VpcId == a.destination.VpcId {
+			a.addHop(pathHop{
+				HopType:    hopTypeRoute,
+				ResourceId: a.source.VpcId,
+				Action:     HopActionAllow,
+				Reason:     "source and destination are in the same VPC (local route)",
+			})
+		} else {
+			allowed, err := a.evaluateRoute(ctx)
+			if err != nil || !allowed {
+				return false, err
+			}
+		}
+	}
+
+	// 3. Ingress of the destination security groups
+	if len(a.destination.VirtualServerId) != 0 {
+		hop, err := a.evaluateSecurityGroups(ctx, a.destination.SecurityGroupIds, "IN", a.source.Ip, hopTypeSecurityGroupIngress)
+		if err != nil {
+			return false, err
+		}
+		if !a.addHop(hop) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (a *pathAnalyzer) evaluateSecurityGroups(ctx context.Context, securityGroupIds []string, direction string, remoteIp net.IP, hopType string) (pathHop, error) {
+	for _, securityGroupId := range securityGroupIds {
+		rules, err := a.inst.Client.SecurityGroup.ListSecurityGroupRules(ctx, securityGroupId, &securitygroup2.SecurityGroupOpenApiControllerV2ApiListSecurityGroupRuleV2Opts{
+			RuleDirection: optional.NewString(direction),
+			Page:          optional.NewInt32(0),
+			Size:          optional.NewInt32(10000),
+		})
+		if err != nil {
+			return pathHop{}, err
+		}
+
+		for _, rule := range rules.Contents {
+			if rule.RuleState != common.ActiveState || !strings.EqualFold(rule.RuleDirection, direction) {
+				continue
+			}
+			cidr, ok := findAddressContainsIp(rule.TargetNetworks, remoteIp)
+			if !ok {
+				continue
+			}
+			isAllService := rule.IsAllService != nil && *rule.IsAllService
+			if !servicesContain(isAllService, rule.TcpServices, rule.UdpServices, rule.IcmpServices, a.protocol, a.port) {
+				continue
+			}
+			return pathHop{
+				HopType:     hopType,
+				ResourceId:  securityGroupId,
+				RuleId:      rule.RuleId,
+				MatchedCidr: cidr,
+				Action:      HopActionAllow,
+				Reason:      fmt.Sprintf("allowed by security group rule %s", rule.RuleId),
+			}, nil
+		}
+	}
+
+	return pathHop{
+		HopType:    hopType,
+		ResourceId: strings.Join(securityGroupIds, ","),
+		Action:     HopActionDeny,
+		Reason:     fmt.Sprintf("no %s rule of the attached security groups allows %s", strings.ToLower(direction), a.trafficString()),
+	}, nil
+}
+
+func (a *pathAnalyzer) evaluateRoute(ctx context.Context) (bool, error) {
+	tables, err := a.inst.Client.Routing.GetVpcRoutingTableListByVpcId(ctx, a.source.VpcId)
+	if err != nil {
+		return false, err
+	}
+
+	var routingTableId, routingRuleId, matchedCidr, interfaceId, interfaceName string
+	longestPrefix := -1
+	for _, table := range tables.Contents {
+		rules, err := a.inst.Client.Routing.GetVpcRoutingRulesList(ctx, table.RoutingTableId, routing.ListVpcRoutingRulesRequest{})
+		if err != nil {
+			return false, err
+		}
+		for _, rule := range rules.Contents {
+			if rule.RoutingRuleState != common.ActiveState || !addressContainsIp(rule.DestinationNetworkCidr, a.destination.Ip) {
+				continue
+			}
+			// Longest prefix match
+			if prefix := cidrPrefixLength(rule.DestinationNetworkCidr); prefix > longestPrefix {
+				longestPrefix = prefix
+				routingTableId = table.RoutingTableId
+				routingRuleId = rule.RoutingRuleId
+				matchedCidr = rule.DestinationNetworkCidr
+				interfaceId = rule.SourceServiceInterfaceId
+				interfaceName = rule.SourceServiceInterfaceName
+			}
+		}
+	}
+
+	if longestPrefix < 0 {
+		a.addHop(pathHop{
+			HopType:    hopTypeRoute,
+			ResourceId: a.source.VpcId,
+			Action:     HopActionDeny,
+			Reason:     fmt.Sprintf("no routing rule of VPC %s matches destination %s", a.source.VpcId, a.destination.Ip),
+		})
+		return false, nil
+	}
+
+	a.addHop(pathHop{
+		HopType:     hopTypeRoute,
+		ResourceId:  routingTableId,
+		RuleId:      routingRuleId,
+		MatchedCidr: matchedCidr,
+		Action:      HopActionAllow,
+		Reason:      fmt.Sprintf("routed to %s (%s)", interfaceName, interfaceId),
+	})
+
+	// Firewall of the source side gateway
+	if allowed, err := a.evaluateFirewall(ctx, a.source.VpcId, interfaceId, "OUT"); err != nil || !allowed {
+		return false, err
+	}
+
+	allowed, destinationTargetId, err := a.evaluateGateway(ctx, interfaceId)
+	if err != nil || !allowed {
+		return false, err
+	}
+
+	// Firewall of the destination side gateway
+	if len(a.destination.VpcId) != 0 && len(destinationTargetId) != 0 {
+		if allowed, err := a.evaluateFirewall(ctx, a.destination.VpcId, destinationTargetId, "IN"); err != nil || !allowed {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// evaluateGateway checks the state of the peering or transit gateway used by the route.
+// Returns the firewall target id of the destination side.
+func (a *pathAnalyzer) evaluateGateway(ctx context.Context, interfaceId string) (bool, string, error) {
+	peerings, err := a.inst.Client.Peering.GetVpcPeeringList(ctx, peering.VpcPeeringListRequest{
+		Page: 0,
+		Size: 10000,
+	})
+	if err != nil {
+		return false, "", err
+	}
+	for _, p := range peerings.Contents {
+		if p.VpcPeeringId != interfaceId {
+			continue
+		}
+		if p.VpcPeeringState != common.ActiveState {
+			return a.addHop(pathHop{
+				HopType:    hopTypePeering,
+				ResourceId: p.VpcPeeringId,
+				Action:     HopActionDeny,
+				Reason:     fmt.Sprintf("VPC peering is in %s state", p.VpcPeeringState),
+			}), "", nil
+		}
+		peerVpcId := p.ApproverVpcId
+		if peerVpcId == a.source.VpcId {
+			peerVpcId = p.RequesterVpcId
+		}
+		if len(a.destination.VpcId) != 0 && peerVpcId != a.destination.VpcId {
+			return a.addHop(pathHop{
+				HopType:    hopTypePeering,
+				ResourceId: p.VpcPeeringId,
+				Action:     HopActionDeny,
+				Reason:     fmt.Sprintf("VPC peering connects to VPC %s, not to destination VPC %s", peerVpcId, a.destination.VpcId),
+			}), "", nil
+		}
+		return a.addHop(pathHop{
+			HopType:    hopTypePeering,
+			ResourceId: p.VpcPeeringId,
+			Action:     HopActionAllow,
+			Reason:     fmt.Sprintf("VPC peering to VPC %s is active", peerVpcId),
+		}), p.VpcPeeringId, nil
+	}
+
+	connections, _, err := a.inst.Client.TransitGateway.GetTransitGatewayConnectionList(ctx, &transitgateway2.TransitGatewayConnectionOpenApiControllerApiListTransitGatewayConnectionsOpts{
+		ApproverVpcId: optional.NewString(a.source.VpcId),
+		Page:          optional.NewInt32(0),
+		Size:          optional.NewInt32(10000),
+	})
+	if err != nil {
+		return false, "", err
+	}
+	for _, c := range connections.Contents {
+		if c.TransitGatewayConnectionId != interfaceId && c.RequesterTransitGatewayId != interfaceId {
+			continue
+		}
+		if c.TransitGatewayConnectionState != common.ActiveState {
+			return a.addHop(pathHop{
+				HopType:    hopTypeTransitGateway,
+				ResourceId: c.TransitGatewayConnectionId,
+				Action:     HopActionDeny,
+				Reason:     fmt.Sprintf("transit gateway connection is in %s state", c.TransitGatewayConnectionState),
+			}), "", nil
+		}
+
+		if len(a.destination.VpcId) == 0 {
+			return a.addHop(pathHop{
+				HopType:    hopTypeTransitGateway,
+				ResourceId: c.TransitGatewayConnectionId,
+				Action:     HopActionAllow,
+				Reason:     fmt.Sprintf("transit gateway connection to %s is active", c.RequesterTransitGatewayId),
+			}), "", nil
+		}
+
+		// Destination VPC must be connected to the same transit gateway
+		destinationConnections, _, err := a.inst.Client.TransitGateway.GetTransitGatewayConnectionList(ctx, &transitgateway2.TransitGatewayConnectionOpenApiControllerApiListTransitGatewayConnectionsOpts{
+			RequesterTransitGatewayId: optional.NewString(c.RequesterTransitGatewayId),
+			ApproverVpcId:             optional.NewString(a.destination.VpcId),
+			Page:                      optional.NewInt32(0),
+			Size:                      optional.NewInt32(10000),
+		})
+		if err != nil {
+			return false, "", err
+		}
+		for _, dc := range destinationConnections.Contents {
+			if dc.TransitGatewayConnectionState == common.ActiveState {
+				return a.addHop(pathHop{
+					HopType:    hopTypeTransitGateway,
+					ResourceId: c.RequesterTransitGatewayId,
+					Action:     HopActionAllow,
+					Reason:     fmt.Sprintf("connections %s and %s of the transit gateway are active", c.TransitGatewayConnectionId, dc.TransitGatewayConnectionId),
+				}), dc.TransitGatewayConnectionId, nil
+			}
+		}
+		return a.addHop(pathHop{
+			HopType:    hopTypeTransitGateway,
+			ResourceId: c.RequesterTransitGatewayId,
+			Action:     HopActionDeny,
+			Reason:     fmt.Sprintf("destination VPC %s has no active connection to the transit gateway", a.destination.VpcId),
+		}), "", nil
+	}
+
+	// Other gateways (Internet Gateway, NAT Gateway, Direct Connect, ...) are not evaluated
+	return true, "", nil
+}
+
+func (a *pathAnalyzer) evaluateFirewall(ctx context.Context, vpcId string, targetId string, direction string) (bool, error) {
+	if len(targetId) == 0 {
+		return true, nil
+	}
+
+	firewalls, _, err := a.inst.Client.Firewall.GetFirewallList(ctx, vpcId, targetId, "")
+	if err != nil {
+		return false, err
+	}
+
+	for _, fw := range firewalls.Contents {
+		if fw.ObjectId != targetId {
+			continue
+		}
+		firewallInfo, _, err := a.inst.Client.Firewall.GetFirewall(ctx, fw.FirewallId)
+		if err != nil {
+			return false, err
+		}
+		if firewallInfo.IsEnabled == nil || !*firewallInfo.IsEnabled {
+			a.addHop(pathHop{
+				HopType:    hopTypeFirewall,
+				ResourceId: fw.FirewallId,
+				Action:     HopActionAllow,
+				Reason:     "firewall is disabled",
+			})
+			continue
+		}
+
+		rules, _, err := a.inst.Client.Firewall.ListFirewallRules(ctx, fw.FirewallId)
+		if err != nil {
+			return false, err
+		}
+
+		// Firewall rules are evaluated in order. The first matching rule decides the action.
+		decided := false
+		for _, rule := range rules.Contents {
+			if rule.IsRuleEnabled == nil || !*rule.IsRuleEnabled || !directionMatches(rule.RuleDirection, direction) {
+				continue
+			}
+			if _, ok := findAddressContainsIp(rule.SourceIpAddresses, a.source.Ip); !ok {
+				continue
+			}
+			cidr, ok := findAddressContainsIp(rule.DestinationIpAddresses, a.destination.Ip)
+			if !ok {
+				continue
+			}
+			isAllService := rule.IsAllService != nil && *rule.IsAllService
+			if !servicesContain(isAllService, rule.TcpServices, rule.UdpServices, rule.IcmpServices, a.protocol, a.port) {
+				continue
+			}
+
+			action := HopActionDeny
+			if strings.ToUpper(rule.RuleAction) == "ALLOW" {
+				action = HopActionAllow
+			}
+			if !a.addHop(pathHop{
+				HopType:     hopTypeFirewall,
+				ResourceId:  fw.FirewallId,
+				RuleId:      rule.RuleId,
+				MatchedCidr: cidr,
+				Action:      action,
+				Reason:      fmt.Sprintf("%s by firewall rule %s", strings.ToLower(rule.RuleAction), rule.RuleId),
+			}) {
+				return false, nil
+			}
+			decided = true
+			break
+		}
+
+		if !decided {
+			a.addHop(pathHop{
+				HopType:    hopTypeFirewall,
+				ResourceId: fw.FirewallId,
+				Action:     HopActionDeny,
+				Reason:     fmt.Sprintf("no %s rule of the firewall allows %s (default deny)", strings.ToLower(direction), a.trafficString()),
+			})
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (a *pathAnalyzer) trafficString() string {
+	if a.protocol == ProtocolIcmp {
+		return fmt.Sprintf("%s from %s to %s", a.protocol, a.source.Ip, a.destination.Ip)
+	}
+	return fmt.Sprintf("%s/%d from %s to %s", a.protocol, a.port, a.source.Ip, a.destination.Ip)
+}
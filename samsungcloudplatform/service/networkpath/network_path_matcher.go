@@ -0,0 +1,120 @@
+package networkpath
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	ProtocolTcp  string = "TCP"
+	ProtocolUdp  string = "UDP"
+	ProtocolIcmp string = "ICMP"
+
+	HopActionAllow string = "ALLOW"
+	HopActionDeny  string = "DENY"
+)
+
+// addressContainsIp checks whether the ip is included in the given address.
+// The address can be a single ip (treated as /32) or a cidr block.
+func addressContainsIp(address string, ip net.IP) bool {
+	address = strings.TrimSpace(address)
+	if len(address) == 0 || ip == nil {
+		return false
+	}
+	if !strings.Contains(address, "/") {
+		address = address + "/32"
+	}
+	_, network, err := net.ParseCIDR(address)
+	if err != nil {
+		return false
+	}
+	return network.Contains(ip)
+}
+
+// findAddressContainsIp returns the first address which includes the ip
+func findAddressContainsIp(addresses []string, ip net.IP) (string, bool) {
+	for _, address := range addresses {
+		if addressContainsIp(address, ip) {
+			return address, true
+		}
+	}
+	return "", false
+}
+
+// cidrPrefixLength returns the prefix length of the cidr, or -1 if it is not a valid cidr
+func cidrPrefixLength(cidr string) int {
+	if !strings.Contains(cidr, "/") {
+		cidr = cidr + "/32"
+	}
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return -1
+	}
+	ones, _ := network.Mask.Size()
+	return ones
+}
+
+// portValueContains checks whether the port is included in the service value.
+// Service value can be a single port ("80"), a range ("8000-8080") or empty/"ALL" for every port.
+func portValueContains(value string, port int) bool {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 || strings.ToUpper(value) == "ALL" || value == "*" {
+		return true
+	}
+	if port < 0 {
+		return false
+	}
+
+	if strings.Contains(value, "-") {
+		ports := strings.SplitN(value, "-", 2)
+		from, err := strconv.Atoi(strings.TrimSpace(ports[0]))
+		if err != nil {
+			return false
+		}
+		to, err := strconv.Atoi(strings.TrimSpace(ports[1]))
+		if err != nil {
+			return false
+		}
+		return from <= port && port <= to
+	}
+
+	single, err := strconv.Atoi(value)
+	if err != nil {
+		return false
+	}
+	return single == port
+}
+
+// servicesContain checks whether the protocol/port pair is allowed by the services of a rule
+func servicesContain(isAllService bool, tcpServices []string, udpServices []string, icmpServices []string, protocol string, port int) bool {
+	if isAllService {
+		return true
+	}
+
+	var services []string
+	switch strings.ToUpper(protocol) {
+	case ProtocolTcp:
+		services = tcpServices
+	case ProtocolUdp:
+		services = udpServices
+	case ProtocolIcmp:
+		// ICMP does not use ports. Any ICMP service matches.
+		return len(icmpServices) > 0
+	default:
+		return false
+	}
+
+	for _, service := range services {
+		if portValueContains(service, port) {
+			return true
+		}
+	}
+	return false
+}
+
+// directionMatches checks whether the rule direction covers the requested direction
+func directionMatches(ruleDirection string, direction string) bool {
+	ruleDirection = strings.ToUpper(ruleDirection)
+	return ruleDirection == strings.ToUpper(direction) || ruleDirection == "IN_OUT"
+}
@@ -0,0 +1,104 @@
+package networkpath
+
+import (
+	"net"
+	"testing"
+)
+
+func TestAddressContainsIp(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		ip       string
+		expected bool
+	}{
+		{"single ip", "10.0.0.1", "10.0.0.1", true},
+		{"other ip", "10.0.0.1", "10.0.0.2", false},
+		{"cidr", "10.0.0.0/24", "10.0.0.255", true},
+		{"out of cidr", "10.0.0.0/24", "10.0.1.1", false},
+		{"any", "0.0.0.0/0", "192.168.0.1", true},
+		{"spaces", " 10.0.0.0/8 ", "10.1.2.3", true},
+		{"empty", "", "10.0.0.1", false},
+		{"invalid", "10.0.0.0/33", "10.0.0.1", false},
+		{"nil ip", "10.0.0.0/8", "", false},
+	}
+	for _, test := range tests {
+		if contained := addressContainsIp(test.address, net.ParseIP(test.ip)); contained != test.expected {
+			t.Errorf("%s : expected %v, got %v", test.name, test.expected, contained)
+		}
+	}
+}
+
+func TestCidrPrefixLength(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		expected int
+	}{
+		{"10.0.0.0/16", 16},
+		{"0.0.0.0/0", 0},
+		{"10.0.0.1", 32},
+		{"10.0.0.0/33", -1},
+		{"address", -1},
+	}
+	for _, test := range tests {
+		if length := cidrPrefixLength(test.cidr); length != test.expected {
+			t.Errorf("%s : expected %d, got %d", test.cidr, test.expected, length)
+		}
+	}
+}
+
+func TestPortValueContains(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		port     int
+		expected bool
+	}{
+		{"single port", "80", 80, true},
+		{"other port", "80", 443, false},
+		{"range", "8000-8080", 8080, true},
+		{"range with spaces", "8000 - 8080", 8000, true},
+		{"out of range", "8000-8080", 8081, false},
+		{"empty", "", 22, true},
+		{"all", "all", 22, true},
+		{"asterisk", "*", 22, true},
+		{"any port of all", "ALL", -1, true},
+		{"no port", "80", -1, false},
+		{"invalid", "http", 80, false},
+		{"invalid range", "80-http", 80, false},
+	}
+	for _, test := range tests {
+		if contained := portValueContains(test.value, test.port); contained != test.expected {
+			t.Errorf("%s : expected %v, got %v", test.name, test.expected, contained)
+		}
+	}
+}
+
+func TestServicesContain(t *testing.T) {
+	tcpServices := []string{"22", "8000-8080"}
+	udpServices := []string{"53"}
+
+	tests := []struct {
+		name         string
+		isAllService bool
+		icmpServices []string
+		protocol     string
+		port         int
+		expected     bool
+	}{
+		{"all service", true, nil, ProtocolUdp, 1234, true},
+		{"tcp port", false, nil, ProtocolTcp, 22, true},
+		{"tcp range", false, nil, "tcp", 8080, true},
+		{"tcp other port", false, nil, ProtocolTcp, 53, false},
+		{"udp port", false, nil, ProtocolUdp, 53, true},
+		{"udp other port", false, nil, ProtocolUdp, 22, false},
+		{"icmp", false, []string{"ALL"}, ProtocolIcmp, -1, true},
+		{"no icmp", false, nil, ProtocolIcmp, -1, false},
+		{"unknown protocol", false, nil, "SCTP", 22, false},
+	}
+	for _, test := range tests {
+		if contained := servicesContain(test.isAllService, tcpServices, udpServices, test.icmpServices, test.protocol, test.port); contained != test.expected {
+			t.Errorf("%s : expected %v, got %v", test.name, test.expected, contained)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"strings"
 )
@@ -75,8 +76,54 @@ func ResourceSecurityGroupRule() *schema.Resource {
 			},
 			"addresses_ipv4": {
 				Type:        schema.TypeList,
-				Required:    true,
-				Description: "SecurityGroup Rule target cidr addresses",
+				Optional:    true,
+				Description: "SecurityGroup Rule target cidr addresses. One of addresses_ipv4, remote_security_group_ids or ip_set must be specified.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"remote_security_group_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Security group ids whose virtual server ip addresses are used as rule target addresses. Each security group must have a virtual server with an ip address on plan",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ip_set": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Named ip sets used as rule target addresses",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "IP set name",
+						},
+						"virtual_server_ids": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Virtual server ids whose ip addresses are included in the ip set",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"addresses_ipv4": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Cidr addresses included in the ip set",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"resolved_addresses_ipv4": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rule target cidr addresses resolved from addresses_ipv4, remote_security_group_ids and ip_set",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -100,7 +147,12 @@ func ResourceSecurityGroupRule() *schema.Resource {
 					},
 				},
 			},
+			"overlap_check": overlapCheckSchema(),
 		},
+		CustomizeDiff: customdiff.Sequence(
+			resourceSecurityGroupRuleReferenceDiff,
+			resourceSecurityGroupRuleDiff,
+		),
 		Description: "Provides a Security Group Rule resource.",
 	}
 }
@@ -109,6 +161,7 @@ func ResourceSecurityGroupBulkRule() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceSecurityGroupBulkRuleCreate,
 		ReadContext:   resourceSecurityGroupBulkRuleRead,
+		UpdateContext: schema.NoopContext,
 		DeleteContext: resourceSecurityGroupRuleAllDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -196,8 +249,10 @@ func ResourceSecurityGroupBulkRule() *schema.Resource {
 				},
 				Description: "Security Group Rule List",
 			},
+			"overlap_check": overlapCheckSchema(),
 		},
-		Description: "Provides a Security Group Bulk Rule resource.",
+		CustomizeDiff: resourceSecurityGroupBulkRuleDiff,
+		Description:   "Provides a Security Group Bulk Rule resource.",
 	}
 }
 
@@ -293,8 +348,14 @@ func resourceSecurityGroupRuleCreate(ctx context.Context, rd *schema.ResourceDat
 	direction := rd.Get("direction").(string)
 	description := rd.Get("description").(string)
 	fmt.Println("OOO" + direction)
+
+	inst := meta.(*client.Instance)
+
 	// Addresses
-	addressesIpv4 := expandAddressesIpv4(rd)
+	addressesIpv4, err := getRuleAddressesFromResourceData(ctx, inst, rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Services
 	services, err := expandServices(rd)
@@ -302,8 +363,6 @@ func resourceSecurityGroupRuleCreate(ctx context.Context, rd *schema.ResourceDat
 		diag.FromErr(err)
 	}
 
-	inst := meta.(*client.Instance)
-
 	response, err := inst.Client.SecurityGroup.CreateSecurityGroupRule(ctx, sgId, direction, addressesIpv4, description, services)
 	if err != nil {
 		return diag.FromErr(err)
@@ -352,7 +411,11 @@ func resourceSecurityGroupRuleRead(ctx context.Context, rd *schema.ResourceData,
 
 	rd.Set("direction", info.RuleDirection)
 	rd.Set("description", info.RuleDescription)
-	rd.Set("addresses_ipv4", info.TargetNetworks)
+	references := expandRuleReferences(rd.Get("remote_security_group_ids").([]interface{}), rd.Get("ip_set").([]interface{}))
+	if references.isEmpty() {
+		rd.Set("addresses_ipv4", info.TargetNetworks)
+	}
+	rd.Set("resolved_addresses_ipv4", common.NormalizeCidrList(info.TargetNetworks))
 
 	if *info.IsAllService {
 		s := common.HclSetObject{}
@@ -390,9 +453,12 @@ func resourceSecurityGroupRuleUpdate(ctx context.Context, rd *schema.ResourceDat
 
 	inst := meta.(*client.Instance)
 
-	if rd.HasChanges("direction", "addresses_ipv4", "service", "description") {
+	if rd.HasChanges("direction", "addresses_ipv4", "remote_security_group_ids", "ip_set", "resolved_addresses_ipv4", "service", "description") {
 
-		addressesIpv4 := expandAddressesIpv4(rd)
+		addressesIpv4, err := getRuleAddressesFromResourceData(ctx, inst, rd)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 		services, err := expandServices(rd)
 		if err != nil {
 			return diag.FromErr(err)
@@ -0,0 +1,154 @@
+package securitygroup
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	securitygroup2 "github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/security-group2"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func overlapCheckSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     common.OverlapCheckNone,
+		Description: "Plan time rule overlap check mode. (ERROR, WARN, NONE) Defaults to NONE. Duplicated rules are rejected unless NONE. Shadowed rules are rejected in ERROR mode and written to the provider log as warning in WARN mode, which is shown with TF_LOG=WARN.",
+		ValidateFunc: validation.StringInSlice([]string{
+			common.OverlapCheckError,
+			common.OverlapCheckWarn,
+			common.OverlapCheckNone,
+		}, false),
+	}
+}
+
+func toNetworkRule(id string, direction string, addresses []string, services []common.RuleService) common.NetworkRule {
+	rule := common.NetworkRule{
+		Id:        id,
+		Direction: strings.ToUpper(direction),
+		Action:    "ALLOW",
+		Services:  services,
+	}
+	if rule.Direction == "IN" {
+		rule.SourceAddresses = common.NormalizeCidrList(addresses)
+	} else {
+		rule.DestinationAddresses = common.NormalizeCidrList(addresses)
+	}
+	return rule
+}
+
+func expandRuleServices(servicesSet *schema.Set) []common.RuleService {
+	var services []common.RuleService
+	for _, valueService := range servicesSet.List() {
+		s := valueService.(map[string]interface{})
+		services = append(services, common.RuleService{
+			Type:  s["type"].(string),
+			Value: s["value"].(string),
+		})
+	}
+	return services
+}
+
+func securityGroupRuleToNetworkRule(rule securitygroup2.SecurityGroupRuleResponse) common.NetworkRule {
+	var services []common.RuleService
+	if rule.IsAllService != nil && *rule.IsAllService {
+		services = append(services, common.RuleService{Type: "ALL"})
+	}
+	for _, svc := range rule.TcpServices {
+		services = append(services, common.RuleService{Type: "TCP", Value: svc})
+	}
+	for _, svc := range rule.UdpServices {
+		services = append(services, common.RuleService{Type: "UDP", Value: svc})
+	}
+	for _, svc := range rule.IcmpServices {
+		services = append(services, common.RuleService{Type: "ICMP", Value: svc})
+	}
+	return toNetworkRule(rule.RuleId, rule.RuleDirection, rule.TargetNetworks, services)
+}
+
+func listExistingNetworkRules(ctx context.Context, inst *client.Instance, securityGroupId string, excludeRuleId string) ([]common.NetworkRule, error) {
+	rules, err := inst.Client.SecurityGroup.ListSecurityGroupRules(ctx, securityGroupId, &securitygroup2.SecurityGroupOpenApiControllerV2ApiListSecurityGroupRuleV2Opts{
+		Page: optional.NewInt32(0),
+		Size: optional.NewInt32(10000),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []common.NetworkRule
+	for _, rule := range rules.Contents {
+		if rule.RuleId == excludeRuleId || rule.RuleState == common.DeletedState || rule.RuleState == "DELETING" {
+			continue
+		}
+		result = append(result, securityGroupRuleToNetworkRule(rule))
+	}
+	return result, nil
+}
+
+func resourceSecurityGroupRuleDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	mode := diff.Get("overlap_check").(string)
+	if mode == common.OverlapCheckNone {
+		return nil
+	}
+	if len(diff.Id()) != 0 && !diff.HasChanges("direction", "resolved_addresses_ipv4", "service", "overlap_check") {
+		return nil
+	}
+	if !diff.NewValueKnown("security_group_id") || !diff.NewValueKnown("resolved_addresses_ipv4") || !diff.NewValueKnown("service") {
+		return nil
+	}
+
+	// Check the addresses resolved from the references as well as the literal addresses
+	newRule := toNetworkRule("",
+		diff.Get("direction").(string),
+		common.ToStringList(diff.Get("resolved_addresses_ipv4").([]interface{})),
+		expandRuleServices(diff.Get("service").(*schema.Set)))
+
+	inst := meta.(*client.Instance)
+	existingRules, err := listExistingNetworkRules(ctx, inst, diff.Get("security_group_id").(string), diff.Id())
+	if err != nil {
+		return err
+	}
+
+	duplicates, shadowed := common.CheckRuleOverlaps([]common.NetworkRule{newRule}, existingRules)
+	return common.RuleOverlapError(mode, duplicates, shadowed)
+}
+
+func resourceSecurityGroupBulkRuleDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	mode := diff.Get("overlap_check").(string)
+	if mode == common.OverlapCheckNone {
+		return nil
+	}
+	if len(diff.Id()) != 0 && !diff.HasChanges("rule", "overlap_check") {
+		return nil
+	}
+	if !diff.NewValueKnown("security_group_id") || !diff.NewValueKnown("rule") {
+		return nil
+	}
+
+	var newRules []common.NetworkRule
+	for _, rule := range diff.Get("rule").(*schema.Set).List() {
+		itemObject := rule.(common.HclKeyValueObject)
+		newRules = append(newRules, toNetworkRule("",
+			itemObject["direction"].(string),
+			common.ToStringList(itemObject["addresses_ipv4"].([]interface{})),
+			expandRuleServices(itemObject["service"].(*schema.Set))))
+	}
+
+	// Replacing bulk rules deletes every rule of the security group first
+	var existingRules []common.NetworkRule
+	if len(diff.Id()) == 0 {
+		var err error
+		inst := meta.(*client.Instance)
+		existingRules, err = listExistingNetworkRules(ctx, inst, diff.Get("security_group_id").(string), "")
+		if err != nil {
+			return err
+		}
+	}
+
+	duplicates, shadowed := common.CheckRuleOverlaps(newRules, existingRules)
+	return common.RuleOverlapError(mode, duplicates, shadowed)
+}
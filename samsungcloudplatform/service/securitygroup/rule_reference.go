@@ -0,0 +1,242 @@
+package securitygroup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/virtualserver"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ruleReferences holds the address sources of a security group rule other than literal cidrs
+type ruleReferences struct {
+	SecurityGroupIds []string
+	IpSets           []ruleIpSet
+}
+
+type ruleIpSet struct {
+	Name             string
+	VirtualServerIds []string
+	Addresses        []string
+}
+
+func (r ruleReferences) isEmpty() bool {
+	return len(r.SecurityGroupIds) == 0 && len(r.IpSets) == 0
+}
+
+func expandRuleReferences(securityGroupIds []interface{}, ipSets []interface{}) ruleReferences {
+	references := ruleReferences{
+		SecurityGroupIds: common.ToStringList(securityGroupIds),
+	}
+	for _, item := range ipSets {
+		itemObject := item.(map[string]interface{})
+		references.IpSets = append(references.IpSets, ruleIpSet{
+			Name:             itemObject["name"].(string),
+			VirtualServerIds: common.ToStringList(itemObject["virtual_server_ids"].([]interface{})),
+			Addresses:        common.ToStringList(itemObject["addresses_ipv4"].([]interface{})),
+		})
+	}
+	return references
+}
+
+// getVirtualServerIps returns ip addresses of every network interface of the virtual server
+func getVirtualServerIps(ctx context.Context, inst *client.Instance, virtualServerId string) ([]string, error) {
+	nicInfoList, err := inst.Client.VirtualServer.GetNicList(ctx, virtualServerId)
+	if err != nil {
+		return nil, err
+	}
+	var ips []string
+	for _, nic := range nicInfoList.Contents {
+		if len(nic.Ip) != 0 {
+			ips = append(ips, nic.Ip)
+		}
+	}
+	return ips, nil
+}
+
+// securityGroupMembersListPageSize is the page size of the virtual server list read to find the security group members
+const securityGroupMembersListPageSize = 1000
+
+// listSecurityGroupMembers returns ids of the virtual servers attached to every security group.
+// The virtual server list does not have the security groups, so the detail of each virtual server is read.
+// The members are not cached across calls, so the plan and the apply read the current members.
+func listSecurityGroupMembers(ctx context.Context, inst *client.Instance) (map[string][]string, error) {
+	members := make(map[string][]string)
+	request := virtualserver.ListVirtualServersRequestParam{
+		Size: securityGroupMembersListPageSize,
+		Sort: "createdDt:asc",
+	}
+	for listed := 0; ; request.Page++ {
+		virtualServers, err := inst.Client.VirtualServer.ListVirtualServers(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, virtualServer := range virtualServers.Contents {
+			virtualServerInfo, _, err := inst.Client.VirtualServer.GetVirtualServer(ctx, virtualServer.VirtualServerId)
+			if err != nil {
+				if common.IsDeleted(err) {
+					continue
+				}
+				return nil, err
+			}
+			if virtualServerInfo.VirtualServerState == common.DeletedState || virtualServerInfo.VirtualServerState == common.TerminatingState {
+				continue
+			}
+			for _, sg := range virtualServerInfo.SecurityGroupIds {
+				members[sg.SecurityGroupId] = append(members[sg.SecurityGroupId], virtualServerInfo.VirtualServerId)
+			}
+		}
+		listed += len(virtualServers.Contents)
+		if len(virtualServers.Contents) == 0 || listed >= int(virtualServers.TotalCount) {
+			break
+		}
+	}
+	return members, nil
+}
+
+// getSecurityGroupMemberIds returns ids of virtual servers attached to each of the security groups
+func getSecurityGroupMemberIds(ctx context.Context, inst *client.Instance, securityGroupIds []string) (map[string][]string, error) {
+	for _, securityGroupId := range securityGroupIds {
+		if _, _, err := inst.Client.SecurityGroup.GetSecurityGroup(ctx, securityGroupId); err != nil {
+			return nil, fmt.Errorf("failed to get referenced security group %s : %w", securityGroupId, err)
+		}
+	}
+
+	allMembers, err := listSecurityGroupMembers(ctx, inst)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string][]string)
+	for _, securityGroupId := range securityGroupIds {
+		members[securityGroupId] = append([]string{}, allMembers[securityGroupId]...)
+	}
+	return members, nil
+}
+
+// resolveRuleAddresses merges literal addresses and the addresses resolved from the references.
+// Result is normalized with the subnet mask, de-duplicated and sorted.
+// A reference which resolves to no address is an error, since the rule would not match what it refers to.
+func resolveRuleAddresses(ctx context.Context, inst *client.Instance, addresses []string, references ruleReferences) ([]string, error) {
+	resolved := make(map[string]bool)
+	for _, address := range addresses {
+		resolved[common.NormalizeCidr(address)] = true
+	}
+
+	virtualServerIps := make(map[string][]string)
+	getIps := func(virtualServerIds []string) ([]string, error) {
+		var ips []string
+		for _, virtualServerId := range virtualServerIds {
+			if _, ok := virtualServerIps[virtualServerId]; !ok {
+				serverIps, err := getVirtualServerIps(ctx, inst, virtualServerId)
+				if err != nil {
+					return nil, fmt.Errorf("failed to resolve ip addresses of virtual server %s : %w", virtualServerId, err)
+				}
+				virtualServerIps[virtualServerId] = serverIps
+			}
+			ips = append(ips, virtualServerIps[virtualServerId]...)
+		}
+		return ips, nil
+	}
+
+	if len(references.SecurityGroupIds) != 0 {
+		members, err := getSecurityGroupMemberIds(ctx, inst, references.SecurityGroupIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, securityGroupId := range references.SecurityGroupIds {
+			ips, err := getIps(members[securityGroupId])
+			if err != nil {
+				return nil, err
+			}
+			if len(ips) == 0 {
+				return nil, fmt.Errorf("remote security group %s has no virtual server with an ip address", securityGroupId)
+			}
+			for _, ip := range ips {
+				resolved[common.NormalizeCidr(ip)] = true
+			}
+		}
+	}
+	for _, ipSet := range references.IpSets {
+		ips, err := getIps(ipSet.VirtualServerIds)
+		if err != nil {
+			return nil, err
+		}
+		ips = append(ips, ipSet.Addresses...)
+		if len(ips) == 0 {
+			return nil, fmt.Errorf("ip_set %s resolves to no address", ipSet.Name)
+		}
+		for _, ip := range ips {
+			resolved[common.NormalizeCidr(ip)] = true
+		}
+	}
+
+	result := make([]string, 0, len(resolved))
+	for address := range resolved {
+		result = append(result, address)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// getRuleAddressesFromResourceData returns the rule target addresses on apply.
+// The addresses resolved from the references are taken from the plan, so the applied rule matches the plan.
+func getRuleAddressesFromResourceData(ctx context.Context, inst *client.Instance, rd *schema.ResourceData) ([]string, error) {
+	references := expandRuleReferences(rd.Get("remote_security_group_ids").([]interface{}), rd.Get("ip_set").([]interface{}))
+	if references.isEmpty() {
+		// Literal addresses are passed as they are
+		return expandAddressesIpv4(rd), nil
+	}
+	if planned := common.ToStringList(rd.Get("resolved_addresses_ipv4").([]interface{})); len(planned) != 0 {
+		return planned, nil
+	}
+
+	// The references were not known on plan
+	addresses, err := resolveRuleAddresses(ctx, inst, expandAddressesIpv4(rd), references)
+	if err != nil {
+		return nil, err
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no address is resolved from addresses_ipv4, remote_security_group_ids and ip_set")
+	}
+	return addresses, nil
+}
+
+// resourceSecurityGroupRuleReferenceDiff resolves the referenced addresses on plan,
+// so the rule follows the virtual servers of the referenced security groups and ip sets.
+// Literal addresses are resolved without any api call.
+func resourceSecurityGroupRuleReferenceDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	addresses := common.ToStringList(diff.Get("addresses_ipv4").([]interface{}))
+	references := expandRuleReferences(diff.Get("remote_security_group_ids").([]interface{}), diff.Get("ip_set").([]interface{}))
+
+	if !diff.NewValueKnown("addresses_ipv4") || !diff.NewValueKnown("remote_security_group_ids") || !diff.NewValueKnown("ip_set") {
+		return diff.SetNewComputed("resolved_addresses_ipv4")
+	}
+	if len(addresses) == 0 && references.isEmpty() {
+		return fmt.Errorf("one of addresses_ipv4, remote_security_group_ids or ip_set must be specified")
+	}
+
+	if references.isEmpty() {
+		// Unchanged literal addresses do not update the rule, even if the state has no resolved addresses yet
+		if len(diff.Id()) != 0 && !diff.HasChange("addresses_ipv4") {
+			return nil
+		}
+		return diff.SetNew("resolved_addresses_ipv4", common.NormalizeCidrList(addresses))
+	}
+
+	inst := meta.(*client.Instance)
+	resolved, err := resolveRuleAddresses(ctx, inst, addresses, references)
+	if err != nil {
+		return err
+	}
+
+	old := common.ToStringList(diff.Get("resolved_addresses_ipv4").([]interface{}))
+	if len(diff.Id()) != 0 && strings.Join(common.NormalizeCidrList(old), ",") == strings.Join(resolved, ",") {
+		return nil
+	}
+	return diff.SetNew("resolved_addresses_ipv4", resolved)
+}
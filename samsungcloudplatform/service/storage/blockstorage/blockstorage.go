@@ -26,7 +26,7 @@ func ResourceBlockStorage() *schema.Resource {
 		UpdateContext: updateBlockStorage,
 		DeleteContext: deleteBlockStorage,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importBlockStorage,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -77,7 +77,7 @@ func ResourceBlockStorage() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Description: "Virtual server IDs to which you want to assign the block storage.",
+				Description: "Virtual server IDs to which you want to assign the block storage. Only the listed virtual servers are managed, so attachments made elsewhere (e.g. by samsungcloudplatform_block_storage_attachment) are kept.",
 			},
 			"product_name": {
 				Type:        schema.TypeString,
@@ -96,6 +96,12 @@ func ResourceBlockStorage() *schema.Resource {
 				ForceNew:    true,
 				Description: "The block storage whether to use encryption. This can be enabled when the virtual server is encryption enabled.",
 			},
+			"source_snapshot_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Snapshot ID from which to create the block storage. storage_size_gb must be at least the snapshot size.",
+			},
 			"tags": tfTags.TagsSchema(),
 		},
 		Description: "Provides a Block Storage resource.",
@@ -124,6 +130,20 @@ func createBlockStorage(ctx context.Context, data *schema.ResourceData, meta int
 		finalVirtualServerId = virtualServerIds[0]
 	}
 
+	snapshotId := data.Get("source_snapshot_id").(string)
+	if len(snapshotId) > 0 {
+		snapshotInfo, _, err := inst.Client.BlockStorage.ReadBlockStorageSnapshot(ctx, snapshotId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if snapshotInfo.SnapshotState != "ACTIVE" {
+			return diag.Errorf("snapshot %s is not available : %s", snapshotId, snapshotInfo.SnapshotState)
+		}
+		if (int32)(data.Get("storage_size_gb").(int)) < snapshotInfo.SnapshotSize {
+			return diag.Errorf("storage_size_gb must be at least the snapshot size %d GB", snapshotInfo.SnapshotSize)
+		}
+	}
+
 	encryptEnable := data.Get("encrypt_enable").(bool) // TODO : (add Validation) Virtual Server 암호화 True -> EncryptEnable도 True가능
 	sharedType := data.Get("shared_type").(string)
 
@@ -139,6 +159,7 @@ func createBlockStorage(ctx context.Context, data *schema.ResourceData, meta int
 		DiskType:         data.Get("product_name").(string),
 		SharedType:       sharedType,
 		VirtualServerId:  finalVirtualServerId,
+		SnapshotId:       snapshotId,
 	}, data.Get("tags").(map[string]interface{}))
 
 	if err != nil {
@@ -172,8 +193,7 @@ func readBlockStorage(ctx context.Context, data *schema.ResourceData, meta inter
 	data.Set("encrypt_enable", info.EncryptEnabled)
 	data.Set("product_id", info.ProductId)
 	data.Set("shared_type", info.SharedType)
-	virtualServerIds := getVirtualServerIds(info)
-	data.Set("virtual_server_ids", virtualServerIds)
+	data.Set("virtual_server_ids", getManagedVirtualServerIds(data, getVirtualServerIds(info)))
 
 	tfTags.SetTags(ctx, data, meta, data.Id())
 
@@ -196,6 +216,35 @@ func getVirtualServerIds(info blockstorage2.BlockStorageResponse) []string {
 	return virtualServerIds
 }
 
+// getManagedVirtualServerIds returns the attached virtual servers listed in virtual_server_ids.
+// Virtual servers attached outside of virtual_server_ids are not managed by this resource.
+func getManagedVirtualServerIds(data *schema.ResourceData, attachedIds []string) []string {
+	attached := make(map[string]bool)
+	for _, attachedId := range attachedIds {
+		attached[attachedId] = true
+	}
+	managedIds := make([]string, 0)
+	for _, virtualServerId := range data.Get("virtual_server_ids").([]interface{}) {
+		if attached[virtualServerId.(string)] {
+			managedIds = append(managedIds, virtualServerId.(string))
+		}
+	}
+	return managedIds
+}
+
+// importBlockStorage regards every attached virtual server as managed by virtual_server_ids
+func importBlockStorage(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	inst := meta.(*client.Instance)
+
+	info, _, err := inst.Client.BlockStorage.ReadBlockStorage(ctx, data.Id())
+	if err != nil {
+		return nil, err
+	}
+	data.Set("virtual_server_ids", getVirtualServerIds(info))
+
+	return []*schema.ResourceData{data}, nil
+}
+
 // Block Storage Resize
 func updateBlockStorage(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	inst := meta.(*client.Instance)
@@ -0,0 +1,268 @@
+package blockstorage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/storage/blockstorage"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/service/virtualserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// virtualServerMutexKV serializes attaching and detaching volumes of a virtual server
+var virtualServerMutexKV = common.NewMutexKV()
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_block_storage_attachment", ResourceBlockStorageAttachment())
+}
+
+func ResourceBlockStorageAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: createBlockStorageAttachment,
+		ReadContext:   readBlockStorageAttachment,
+		UpdateContext: updateBlockStorageAttachment,
+		DeleteContext: deleteBlockStorageAttachment,
+		Importer: &schema.ResourceImporter{
+			StateContext: importBlockStorageAttachment,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"block_storage_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Block storage ID to attach",
+			},
+			"virtual_server_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Virtual server ID to which the block storage is attached",
+			},
+			"stop_before_detach": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, a running virtual server is stopped before the block storage is detached, and started again after it, even if the detach fails.",
+			},
+			"shared_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Shared type of the block storage. (DEDICATED or SHARED)",
+			},
+		},
+		Description: "Provides an attachment of a block storage to a virtual server. A SHARED block storage can have an attachment for each virtual server.",
+	}
+}
+
+func isBlockStorageAttachedTo(ctx context.Context, inst *client.Instance, blockStorageId string, virtualServerId string) (bool, []string, error) {
+	responses, err := inst.Client.BlockStorage.ListBlockStorageVirtualServers(ctx, blockStorageId, blockstorage.BlockStorageVirtualServersRequest{
+		Page: 0,
+		Size: 1000,
+	})
+	if err != nil {
+		return false, nil, err
+	}
+
+	var virtualServerIds []string
+	attached := false
+	for _, virtualServer := range responses.Contents {
+		virtualServerIds = append(virtualServerIds, virtualServer.VirtualServerId)
+		if virtualServer.VirtualServerId == virtualServerId {
+			attached = true
+		}
+	}
+	return attached, virtualServerIds, nil
+}
+
+func createBlockStorageAttachment(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	blockStorageId := data.Get("block_storage_id").(string)
+	virtualServerId := data.Get("virtual_server_id").(string)
+
+	virtualServerMutexKV.Lock(virtualServerId)
+	defer virtualServerMutexKV.Unlock(virtualServerId)
+
+	err := waitForBlockStorageStatus(ctx, inst.Client, blockStorageId, []string{}, []string{"ACTIVE"}, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	info, _, err := inst.Client.BlockStorage.ReadBlockStorage(ctx, blockStorageId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	attached, virtualServerIds, err := isBlockStorageAttachedTo(ctx, inst, blockStorageId, virtualServerId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if attached {
+		return diag.Errorf("block storage %s is already attached to virtual server %s. import it with %s/%s", blockStorageId, virtualServerId, blockStorageId, virtualServerId)
+	}
+	if info.SharedType != "SHARED" && len(virtualServerIds) > 0 {
+		return diag.Errorf("%s block storage %s is already attached to virtual server %s", info.SharedType, blockStorageId, strings.Join(virtualServerIds, ", "))
+	}
+
+	err = virtualserver.WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = inst.Client.BlockStorage.AttachBlockStorage(ctx, blockStorageId, blockstorage.BlockStorageAttachRequest{
+		VirtualServerId: virtualServerId,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = waitForBlockStorageStatus(ctx, inst.Client, blockStorageId, []string{}, []string{"ACTIVE"}, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(blockStorageId + "/" + virtualServerId)
+
+	return readBlockStorageAttachment(ctx, data, meta)
+}
+
+func readBlockStorageAttachment(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	blockStorageId := data.Get("block_storage_id").(string)
+
+	info, _, err := inst.Client.BlockStorage.ReadBlockStorage(ctx, blockStorageId)
+	if err != nil {
+		data.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	attached, _, err := isBlockStorageAttachedTo(ctx, inst, blockStorageId, data.Get("virtual_server_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !attached {
+		data.SetId("")
+		return nil
+	}
+
+	data.Set("shared_type", info.SharedType)
+
+	return nil
+}
+
+func updateBlockStorageAttachment(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// only stop_before_detach can be changed, which is used on destroy
+	return readBlockStorageAttachment(ctx, data, meta)
+}
+
+// deleteBlockStorageAttachment detaches the block storage.
+// If stop_before_detach is true, the running virtual server is stopped first, and started again after the detach even if the detach fails.
+func deleteBlockStorageAttachment(ctx context.Context, data *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
+	inst := meta.(*client.Instance)
+
+	blockStorageId := data.Get("block_storage_id").(string)
+	virtualServerId := data.Get("virtual_server_id").(string)
+
+	virtualServerMutexKV.Lock(virtualServerId)
+	defer virtualServerMutexKV.Unlock(virtualServerId)
+
+	attached, _, err := isBlockStorageAttachedTo(ctx, inst, blockStorageId, virtualServerId)
+	if err != nil {
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if !attached {
+		return nil
+	}
+
+	err = virtualserver.WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if data.Get("stop_before_detach").(bool) {
+		virtualServerInfo, _, err := inst.Client.VirtualServer.GetVirtualServer(ctx, virtualServerId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if virtualServerInfo.VirtualServerState == common.RunningState {
+			_, err = inst.Client.VirtualServer.StopVirtualServer(ctx, virtualServerId)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			defer func() {
+				if err := startVirtualServer(ctx, inst, virtualServerId); err != nil {
+					diagnostics = append(diagnostics, diag.FromErr(err)...)
+				}
+			}()
+			err = virtualserver.WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.StoppedState}, true)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	_, err = inst.Client.BlockStorage.DetachBlockStorage(ctx, blockStorageId, blockstorage.BlockStorageDetachRequest{
+		VirtualServerId: virtualServerId,
+	})
+	if err != nil && !common.IsDeleted(err) {
+		return diag.FromErr(err)
+	}
+
+	err = waitForBlockStorageStatus(ctx, inst.Client, blockStorageId, []string{}, []string{"ACTIVE", "DELETED"}, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// startVirtualServer starts the virtual server stopped for the detach, after it leaves the processing states
+func startVirtualServer(ctx context.Context, inst *client.Instance, virtualServerId string) error {
+	err := virtualserver.WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, true)
+	if err != nil {
+		return err
+	}
+	virtualServerInfo, _, err := inst.Client.VirtualServer.GetVirtualServer(ctx, virtualServerId)
+	if err != nil {
+		return err
+	}
+	if virtualServerInfo.VirtualServerState == common.RunningState {
+		return nil
+	}
+	_, err = inst.Client.VirtualServer.StartVirtualServer(ctx, virtualServerId)
+	if err != nil {
+		return err
+	}
+	return virtualserver.WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+}
+
+// importBlockStorageAttachment imports with "<block_storage_id>/<virtual_server_id>" format
+func importBlockStorageAttachment(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.Split(data.Id(), "/")
+	if len(ids) != 2 || len(ids[0]) == 0 || len(ids[1]) == 0 {
+		return nil, fmt.Errorf("invalid import id %q. expected format is <block_storage_id>/<virtual_server_id>", data.Id())
+	}
+
+	data.Set("block_storage_id", ids[0])
+	data.Set("virtual_server_id", ids[1])
+	data.Set("stop_before_detach", false)
+
+	return []*schema.ResourceData{data}, nil
+}
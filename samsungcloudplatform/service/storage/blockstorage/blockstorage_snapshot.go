@@ -0,0 +1,159 @@
+package blockstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/storage/blockstorage"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_block_storage_snapshot", ResourceBlockStorageSnapshot())
+}
+
+func ResourceBlockStorageSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: createBlockStorageSnapshot,
+		ReadContext:   readBlockStorageSnapshot,
+		DeleteContext: deleteBlockStorageSnapshot,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"block_storage_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Block storage ID to take the snapshot of",
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The snapshot name to create. (3 to 28 characters with -)",
+				ValidateDiagFunc: common.ValidateName3to28Dash,
+			},
+			"description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Snapshot description. (Up to 50 characters)",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(0, 50)),
+			},
+			"snapshot_size_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Storage size(GB) of the block storage when the snapshot is taken",
+			},
+			"encrypt_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the block storage of the snapshot is encrypted",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Snapshot state",
+			},
+			"created_dt": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation time",
+			},
+		},
+		Description: "Provides an on-demand snapshot of a block storage. It waits until the snapshot is available.",
+	}
+}
+
+func createBlockStorageSnapshot(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	blockStorageId := data.Get("block_storage_id").(string)
+
+	err := waitForBlockStorageStatus(ctx, inst.Client, blockStorageId, []string{}, []string{"ACTIVE"}, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	response, err := inst.Client.BlockStorage.CreateBlockStorageSnapshot(ctx, blockStorageId, blockstorage.CreateBlockStorageSnapshotRequest{
+		SnapshotName:        data.Get("name").(string),
+		SnapshotDescription: data.Get("description").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = waitForBlockStorageSnapshotStatus(ctx, inst.Client, response.ResourceId, []string{}, []string{"ACTIVE"}, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(response.ResourceId)
+
+	return readBlockStorageSnapshot(ctx, data, meta)
+}
+
+func readBlockStorageSnapshot(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	info, _, err := inst.Client.BlockStorage.ReadBlockStorageSnapshot(ctx, data.Id())
+	if err != nil {
+		data.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	data.Set("block_storage_id", info.BlockStorageId)
+	data.Set("name", info.SnapshotName)
+	data.Set("description", info.SnapshotDescription)
+	data.Set("snapshot_size_gb", info.SnapshotSize)
+	data.Set("encrypt_enabled", info.EncryptEnabled)
+	data.Set("state", info.SnapshotState)
+	data.Set("created_dt", info.CreatedDt.String())
+
+	return nil
+}
+
+func deleteBlockStorageSnapshot(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	_, err := inst.Client.BlockStorage.DeleteBlockStorageSnapshot(ctx, data.Id())
+	if err != nil && !common.IsDeleted(err) {
+		return diag.FromErr(err)
+	}
+
+	err = waitForBlockStorageSnapshotStatus(ctx, inst.Client, data.Id(), []string{}, []string{"DELETED"}, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func waitForBlockStorageSnapshotStatus(ctx context.Context, scpClient *client.SCPClient, id string, pendingStates []string, targetStates []string, errorOnNotFound bool) error {
+	return client.WaitForStatus(ctx, scpClient, pendingStates, targetStates, func() (interface{}, string, error) {
+		info, c, err := scpClient.BlockStorage.ReadBlockStorageSnapshot(ctx, id)
+		if err != nil {
+			if c == 404 && !errorOnNotFound {
+				return "", "DELETED", nil
+			}
+			if c == 403 && !errorOnNotFound {
+				return "", "DELETED", nil
+			}
+
+			return nil, "", err
+		}
+		if info.SnapshotId != id {
+			return nil, "", fmt.Errorf("invalid resource status")
+		}
+		return info, info.SnapshotState, nil
+	})
+}
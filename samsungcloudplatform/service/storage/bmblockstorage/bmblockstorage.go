@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -40,17 +41,17 @@ func ResourceBmBlockStorage() *schema.Resource {
 			"storage_size_gb": {
 				Type:             schema.TypeInt,
 				Required:         true,
-				Description:      "The storage size(GB) of the block storage to create. (10 to  16384 GB)",
+				Description:      "The storage size(GB) of the block storage to create. (10 to  16384 GB) It can only be increased after creation.",
 				ValidateDiagFunc: validateStorageSize10to16384,
 			},
 			"bm_server_ids": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 				MaxItems:    5,
-				Description: "Baremetal server IDs to which you want to assign the block storage.",
+				Description: "Baremetal server IDs to which you want to assign the block storage. The block storage is attached to or detached from the servers on change.",
 			},
 			"product_name": {
 				Type:        schema.TypeString,
@@ -87,9 +88,6 @@ func ResourceBmBlockStorage() *schema.Resource {
 		Description: "Provides a BM Block Storage resource.",
 		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
 			if diff.Id() != "" {
-				if diff.HasChanges("bm_server_ids") {
-					return nil
-				}
 				if diff.HasChange("name") {
 					return fmt.Errorf("name can't be modified")
 				}
@@ -97,20 +95,14 @@ func ResourceBmBlockStorage() *schema.Resource {
 					return fmt.Errorf("product_name can't be modified")
 				}
 				if diff.HasChange("storage_size_gb") {
-					return fmt.Errorf("storage_size_gb can't be modified")
+					oldValue, newValue := diff.GetChange("storage_size_gb")
+					if newValue.(int) < oldValue.(int) {
+						return fmt.Errorf("storage_size_gb can't be decreased")
+					}
 				}
 				if diff.HasChange("encrypted") {
 					return fmt.Errorf("encrypted can't be modified")
 				}
-				if diff.HasChange("snapshot_policy") {
-					return fmt.Errorf("snapshot_policy can't be modified")
-				}
-				if diff.HasChange("snapshot_capacity_rate") {
-					return fmt.Errorf("snapshot_capacity_rate can't be modified")
-				}
-				if diff.HasChange("snap_shot_schedule") {
-					return fmt.Errorf("snap_shot_schedule can't be modified")
-				}
 			}
 			return nil
 		},
@@ -216,63 +208,71 @@ func validateSnapShotSchedule(v interface{}, path cty.Path) diag.Diagnostics {
 	return diags
 }
 
-func createBmBlockStorage(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	inst := meta.(*client.Instance)
+// getSnapshotSchedule checks and converts snap_shot_schedule. It is empty if there is no schedule.
+func getSnapshotSchedule(snapshotPolicy bool, snapshotScheduleInfo map[string]interface{}) (bmblockstorage.SnapshotSchedule, error) {
+	snapshotSchedule := bmblockstorage.SnapshotSchedule{}
 
-	baremetalServerIds := make([]string, 0)
-	for _, baremetalServerId := range data.Get("bm_server_ids").([]interface{}) {
-		baremetalServerIds = append(baremetalServerIds, baremetalServerId.(string))
+	if len(snapshotScheduleInfo) == 0 {
+		return snapshotSchedule, nil
 	}
 
-	snapshotPolicy := data.Get("snapshot_policy").(bool)
-
-	snapshotScheduleInfo := data.Get("snap_shot_schedule").(map[string]interface{})
-
-	snapshotSchedule := bmblockstorage.SnapshotSchedule{}
+	if !snapshotPolicy {
+		return snapshotSchedule, fmt.Errorf("If Snapshot Policy is false, Snapshot Schedule value should be empty.")
+	}
 
-	if len(snapshotScheduleInfo) != 0 {
+	if snapshotScheduleInfo["frequency"] != nil {
+		snapshotSchedule.Frequency = snapshotScheduleInfo["frequency"].(string)
+	}
+	if snapshotScheduleInfo["day_of_week"] != nil {
+		snapshotSchedule.DayOfWeek = snapshotScheduleInfo["day_of_week"].(string)
+	} else {
+		snapshotSchedule.DayOfWeek = ""
+	}
 
-		if !snapshotPolicy {
-			return diag.Errorf("If Snapshot Policy is false, Snapshot Schedule value should be empty.")
-		}
+	if len(snapshotSchedule.Frequency) <= 0 {
+		return snapshotSchedule, fmt.Errorf("Snapshot schedule frequency is empty.")
+	}
 
-		snapshotSchedule.Frequency = snapshotScheduleInfo["frequency"].(string)
-		if snapshotScheduleInfo["day_of_week"] != nil {
-			snapshotSchedule.DayOfWeek = snapshotScheduleInfo["day_of_week"].(string)
-		} else {
-			snapshotSchedule.DayOfWeek = ""
-		}
+	if strings.ToUpper(snapshotSchedule.Frequency) != "NONE" && snapshotScheduleInfo["hour"] == nil {
+		return snapshotSchedule, fmt.Errorf("Snapshot schedule hour is empty.")
+	}
 
-		if len(snapshotSchedule.Frequency) <= 0 {
-			return diag.Errorf("Snapshot schedule frequency is empty.")
-		}
+	if strings.ToUpper(snapshotSchedule.Frequency) == "WEEKLY" && len(snapshotSchedule.DayOfWeek) <= 0 {
+		return snapshotSchedule, fmt.Errorf("Snapshot schedule day of week is empty.")
+	}
 
-		if strings.ToUpper(snapshotSchedule.Frequency) != "NONE" && snapshotScheduleInfo["hour"] == nil {
-			return diag.Errorf("Snapshot schedule hour is empty.")
-		}
+	if strings.ToUpper(snapshotSchedule.Frequency) == "DAILY" && len(snapshotSchedule.DayOfWeek) > 0 {
+		return snapshotSchedule, fmt.Errorf("Snapshot schedule day of week is unnecessary.")
+	}
 
-		if strings.ToUpper(snapshotSchedule.Frequency) == "WEEKLY" && len(snapshotSchedule.DayOfWeek) <= 0 {
-			return diag.Errorf("Snapshot schedule day of week is empty.")
+	if snapshotScheduleInfo["hour"] != nil {
+		hour, err := strconv.Atoi(snapshotScheduleInfo["hour"].(string))
+		if err != nil {
+			return snapshotSchedule, err
 		}
 
-		if strings.ToUpper(snapshotSchedule.Frequency) == "DAILY" && len(snapshotSchedule.DayOfWeek) > 0 {
-			return diag.Errorf("Snapshot schedule day of week is unnecessary.")
-		}
+		snapshotSchedule.Hour = int32(hour)
+	}
 
-		if snapshotScheduleInfo["hour"] != nil {
+	return snapshotSchedule, nil
+}
 
-			hour, err := strconv.Atoi(snapshotScheduleInfo["hour"].(string))
-			if err != nil {
-				return diag.FromErr(err)
-			}
+func createBmBlockStorage(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
 
-			snapshotSchedule.Hour = int32(hour)
-		}
+	baremetalServerIds := common.ToStringList(data.Get("bm_server_ids").(*schema.Set).List())
+	if len(baremetalServerIds) == 0 {
+		return diag.Errorf("bm_server_ids must have at least one server")
+	}
 
+	snapshotSchedule, err := getSnapshotSchedule(data.Get("snapshot_policy").(bool), data.Get("snap_shot_schedule").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
+	// bm_server_ids is a set, so the first server in the sorted order is used to find the service zone and product
+	sort.Strings(baremetalServerIds)
 	serverInfo, _, err := inst.Client.BareMetal.GetBareMetalServerDetail(ctx, baremetalServerIds[0])
-
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -336,16 +336,27 @@ func readBmBlockStorage(ctx context.Context, data *schema.ResourceData, meta int
 		data.Set("snapshot_policy", snapshotInfo.Contents[0].IsSnapshotPolicy)
 		data.Set("snapshot_capacity_rate", snapshotInfo.Contents[0].SnapshotCapacityRate)
 
-		if len(snapshotInfo.Contents[0].Snapshots) != 0 {
+		if snapshotInfo.Contents[0].IsSnapshotPolicy {
 			scheduleInfo, _, err := inst.Client.BareMetalBlockStorage.GetBareMetalBlockStorageScheduleList(ctx, data.Id())
 
 			if err != nil {
 				return diag.FromErr(err)
 			}
 
-			if len(scheduleInfo.Contents) != 0 {
-				data.Set("snap_shot_schedule", scheduleInfo.Contents[0].SnapshotSchedule)
+			snapshotSchedule := map[string]interface{}{}
+			if len(scheduleInfo.Contents) != 0 && scheduleInfo.Contents[0].SnapshotSchedule != nil {
+				schedule := scheduleInfo.Contents[0].SnapshotSchedule
+				snapshotSchedule["frequency"] = schedule.Frequency
+				if len(schedule.DayOfWeek) != 0 {
+					snapshotSchedule["day_of_week"] = schedule.DayOfWeek
+				}
+				if schedule.Hour != nil {
+					snapshotSchedule["hour"] = strconv.Itoa(int(*schedule.Hour))
+				}
 			}
+			data.Set("snap_shot_schedule", snapshotSchedule)
+		} else {
+			data.Set("snap_shot_schedule", map[string]interface{}{})
 		}
 	}
 
@@ -361,83 +372,117 @@ func getBmServerIds(info baremetalblockstorage.BmBlockStorageDetailResponse) []s
 	return bmServerIds
 }
 
-// 삭제하려면 detach를 해야해서 attach, detach만 구현
 func updateBmBlockStorage(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	inst := meta.(*client.Instance)
 
-	if data.HasChanges("bm_server_ids") {
-		deleteBmIds, addedBmIds := getDetachedAttachedVmIds(data)
+	if data.HasChanges("storage_size_gb") {
+		_, _, err := inst.Client.BareMetalBlockStorage.ResizeBareMetalBlockStorage(ctx, data.Id(), (int32)(data.Get("storage_size_gb").(int)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-		if len(deleteBmIds) != 0 {
-			_, _, err := inst.Client.BareMetalBlockStorage.DetachBareMetalBlockStorage(ctx, data.Id(), deleteBmIds)
+		err = waitForBmBlockStorageStatus(ctx, inst.Client, data.Id(), []string{common.EditingState}, []string{common.ActiveState})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if data.HasChanges("bm_server_ids") {
+		addedBmIds, deletedBmIds := common.GetAddRemoveItemsStringListFromSet(data, "bm_server_ids")
 
+		if len(deletedBmIds) != 0 {
+			_, _, err := inst.Client.BareMetalBlockStorage.DetachBareMetalBlockStorage(ctx, data.Id(), deletedBmIds)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 
 			err = waitForBmBlockStorageStatus(ctx, inst.Client, data.Id(), []string{common.EditingState}, []string{common.ActiveState})
+			if err != nil {
+				return diag.FromErr(err)
+			}
 		}
 
 		if len(addedBmIds) != 0 {
 			_, _, err := inst.Client.BareMetalBlockStorage.AttachBareMetalBlockStorage(ctx, data.Id(), addedBmIds)
-
 			if err != nil {
 				return diag.FromErr(err)
 			}
 
 			err = waitForBmBlockStorageStatus(ctx, inst.Client, data.Id(), []string{common.EditingState}, []string{common.ActiveState})
+			if err != nil {
+				return diag.FromErr(err)
+			}
 		}
+	}
 
+	if data.HasChanges("snapshot_policy", "snapshot_capacity_rate", "snap_shot_schedule") {
+		err := updateBmBlockStorageSnapshotPolicy(ctx, inst, data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = waitForBmBlockStorageStatus(ctx, inst.Client, data.Id(), []string{common.EditingState}, []string{common.ActiveState})
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	tfTags.UpdateTags(ctx, data, meta, data.Id())
+	err := tfTags.UpdateTags(ctx, data, meta, data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return readBmBlockStorage(ctx, data, meta)
 }
 
-func getDetachedAttachedVmIds(data *schema.ResourceData) ([]string, []string) {
-	oldValue, newValue := data.GetChange("bm_server_ids")
-	oldValues := oldValue.([]interface{})
-	newValues := newValue.([]interface{})
-	oldBmIds := make([]string, len(oldValues))
-	newBmIds := make([]string, len(newValues))
-	for i, oldVmId := range oldValues {
-		oldBmIds[i] = oldVmId.(string)
-	}
-	for i, newVmId := range newValues {
-		newBmIds[i] = newVmId.(string)
-	}
-
-	// oldBmIds bmId가 newBmIds에 그대로 있는지 확인
-	deletedBmIds := make([]string, 0)
-	for _, oldBmId := range oldBmIds {
-		var i int
-		for i = 0; i < len(newBmIds); i++ {
-			if strings.Compare(newBmIds[i], oldBmId) == 0 {
-				break
-			}
-		}
-		if i == len(newBmIds) {
-			deletedBmIds = append(deletedBmIds, oldBmId)
-		}
+// updateBmBlockStorageSnapshotPolicy creates, updates or deletes the snapshot attribute and the snapshot schedule
+func updateBmBlockStorageSnapshotPolicy(ctx context.Context, inst *client.Instance, data *schema.ResourceData) error {
+	oldPolicy, newPolicy := data.GetChange("snapshot_policy")
+	oldScheduleInfo, newScheduleInfo := data.GetChange("snap_shot_schedule")
+	hasOldSchedule := oldPolicy.(bool) && len(oldScheduleInfo.(map[string]interface{})) != 0
+	hasNewSchedule := len(newScheduleInfo.(map[string]interface{})) != 0
+
+	snapshotSchedule, err := getSnapshotSchedule(newPolicy.(bool), newScheduleInfo.(map[string]interface{}))
+	if err != nil {
+		return err
 	}
 
-	// oldBmIds bmId가 newBmIds에 그대로 있는지 확인
-	addedBmIds := make([]string, 0)
-	for _, newBmId := range newBmIds {
-		var i int
-		for i = 0; i < len(oldBmIds); i++ {
-			if strings.Compare(newBmId, oldBmIds[i]) == 0 {
-				break
+	if !newPolicy.(bool) {
+		if hasOldSchedule {
+			_, _, err = inst.Client.BareMetalBlockStorage.DeleteBareMetalBlockStorageSchedule(ctx, data.Id())
+			if err != nil {
+				return err
 			}
 		}
-		if i == len(oldBmIds) && len(newBmId) != 0 {
-			addedBmIds = append(addedBmIds, newBmId)
+		if oldPolicy.(bool) {
+			_, _, err = inst.Client.BareMetalBlockStorage.DeleteBareMetalBlockStorageSnapshotAttribute(ctx, data.Id(), "N")
 		}
+		return err
 	}
 
-	return deletedBmIds, addedBmIds
+	snapshotCapacityRate := (int32)(data.Get("snapshot_capacity_rate").(int))
+	if !oldPolicy.(bool) {
+		_, _, err = inst.Client.BareMetalBlockStorage.CreateBareMetalBlockStorageSnapshotAttribute(ctx, data.Id(), "Y", snapshotCapacityRate)
+	} else if data.HasChange("snapshot_capacity_rate") {
+		_, _, err = inst.Client.BareMetalBlockStorage.UpdateBareMetalBlockStorageSnapshotAttribute(ctx, data.Id(), "Y", snapshotCapacityRate)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !data.HasChange("snap_shot_schedule") && hasOldSchedule {
+		return nil
+	}
+
+	if hasOldSchedule && hasNewSchedule {
+		_, _, err = inst.Client.BareMetalBlockStorage.UpdateBareMetalBlockStorageSchedule(ctx, data.Id(), snapshotSchedule)
+	} else if hasNewSchedule {
+		_, _, err = inst.Client.BareMetalBlockStorage.CreateBareMetalBlockStorageSchedule(ctx, data.Id(), snapshotSchedule)
+	} else if hasOldSchedule {
+		_, _, err = inst.Client.BareMetalBlockStorage.DeleteBareMetalBlockStorageSchedule(ctx, data.Id())
+	}
 
+	return err
 }
 
 // Mount 상태를 Unmount 상태로 진행한 후에 삭제 진행해야한다.
@@ -0,0 +1,89 @@
+package bmblockstorage
+
+import (
+	"context"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func init() {
+	samsungcloudplatform.RegisterDataSource("samsungcloudplatform_bm_block_storage_snapshot_capacity", DataSourceBmBlockStorageSnapshotCapacity())
+}
+
+func DataSourceBmBlockStorageSnapshotCapacity() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: bmBlockStorageSnapshotCapacity,
+		Schema: map[string]*schema.Schema{
+			"storage_id":             {Type: schema.TypeString, Required: true, Description: "Baremetal block storage id"},
+			"storage_size_gb":        {Type: schema.TypeInt, Computed: true, Description: "Storage size(GB) of the block storage"},
+			"snapshot_policy":        {Type: schema.TypeBool, Computed: true, Description: "Whether the snapshot is used"},
+			"snapshot_capacity_rate": {Type: schema.TypeInt, Computed: true, Description: "Snapshot capacity rate(%) of the storage size"},
+			"total_capacity_gb":      {Type: schema.TypeInt, Computed: true, Description: "Capacity(GB) for the snapshots"},
+			"used_capacity_gb":       {Type: schema.TypeInt, Computed: true, Description: "Capacity(GB) used by the snapshots"},
+			"available_capacity_gb":  {Type: schema.TypeInt, Computed: true, Description: "Capacity(GB) left for the snapshots"},
+			"usage_rate":             {Type: schema.TypeFloat, Computed: true, Description: "Usage rate(%) of the snapshot capacity"},
+			"snapshots":              {Type: schema.TypeList, Computed: true, Elem: bmBlockStorageSnapshotElem(), Description: "Snapshots of the block storage"},
+		},
+		Description: "Provides the snapshot capacity usage of a Block Storage(BM)",
+	}
+}
+
+func bmBlockStorageSnapshotCapacity(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	storageId := rd.Get("storage_id").(string)
+
+	storageInfo, _, err := inst.Client.BareMetalBlockStorage.GetBareMetalBlockStorageDetail(ctx, storageId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshotInfo, _, err := inst.Client.BareMetalBlockStorage.GetBareMetalBlockStorageSnapshotList(ctx, storageId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshotPolicy := false
+	snapshotCapacityRate := 0
+	snapshotSizes := make([]int, 0)
+	snapshots := make([]map[string]interface{}, 0)
+	if len(snapshotInfo.Contents) != 0 {
+		snapshotPolicy = snapshotInfo.Contents[0].IsSnapshotPolicy
+		snapshotCapacityRate = int(snapshotInfo.Contents[0].SnapshotCapacityRate)
+		for _, snapshot := range snapshotInfo.Contents[0].Snapshots {
+			snapshotSizes = append(snapshotSizes, int(snapshot.SnapshotSize))
+			snapshots = append(snapshots, map[string]interface{}{
+				"snapshot_id":      snapshot.SnapshotId,
+				"snapshot_size_gb": int(snapshot.SnapshotSize),
+				"created_dt":       snapshot.CreatedDt.String(),
+			})
+		}
+	}
+
+	capacity := getSnapshotCapacity(int(storageInfo.BareMetalBlockStorageSize), snapshotCapacityRate, snapshotSizes)
+
+	rd.SetId(storageId)
+	rd.Set("storage_size_gb", storageInfo.BareMetalBlockStorageSize)
+	rd.Set("snapshot_policy", snapshotPolicy)
+	rd.Set("snapshot_capacity_rate", snapshotCapacityRate)
+	rd.Set("total_capacity_gb", capacity.TotalGb)
+	rd.Set("used_capacity_gb", capacity.UsedGb)
+	rd.Set("available_capacity_gb", capacity.AvailableGb)
+	rd.Set("usage_rate", capacity.UsageRate)
+	rd.Set("snapshots", snapshots)
+
+	return nil
+}
+
+func bmBlockStorageSnapshotElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"snapshot_id":      {Type: schema.TypeString, Computed: true, Description: "Snapshot id"},
+			"snapshot_size_gb": {Type: schema.TypeInt, Computed: true, Description: "Snapshot size(GB)"},
+			"created_dt":       {Type: schema.TypeString, Computed: true, Description: "Creation time"},
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package bmblockstorage
+
+// snapshotCapacity is the capacity for the snapshots of a block storage, which is snapshot_capacity_rate percent of the storage size
+type snapshotCapacity struct {
+	TotalGb     int
+	UsedGb      int
+	AvailableGb int
+	UsageRate   float64
+}
+
+func getSnapshotCapacity(storageSizeGb int, snapshotCapacityRate int, snapshotSizesGb []int) snapshotCapacity {
+	capacity := snapshotCapacity{
+		TotalGb: storageSizeGb * snapshotCapacityRate / 100,
+	}
+	for _, size := range snapshotSizesGb {
+		capacity.UsedGb += size
+	}
+
+	capacity.AvailableGb = capacity.TotalGb - capacity.UsedGb
+	if capacity.AvailableGb < 0 {
+		capacity.AvailableGb = 0
+	}
+	if capacity.TotalGb > 0 {
+		capacity.UsageRate = float64(capacity.UsedGb) * 100 / float64(capacity.TotalGb)
+	}
+
+	return capacity
+}
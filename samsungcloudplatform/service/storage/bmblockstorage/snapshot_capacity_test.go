@@ -0,0 +1,24 @@
+package bmblockstorage
+
+import "testing"
+
+func TestGetSnapshotCapacity(t *testing.T) {
+	tests := []struct {
+		name            string
+		storageSizeGb   int
+		capacityRate    int
+		snapshotSizesGb []int
+		expected        snapshotCapacity
+	}{
+		{"no snapshot", 100, 200, nil, snapshotCapacity{TotalGb: 200, UsedGb: 0, AvailableGb: 200, UsageRate: 0}},
+		{"snapshots", 100, 150, []int{30, 45}, snapshotCapacity{TotalGb: 150, UsedGb: 75, AvailableGb: 75, UsageRate: 50}},
+		{"over capacity", 10, 100, []int{8, 8}, snapshotCapacity{TotalGb: 10, UsedGb: 16, AvailableGb: 0, UsageRate: 160}},
+		{"no snapshot policy", 100, 0, nil, snapshotCapacity{}},
+	}
+	for _, test := range tests {
+		capacity := getSnapshotCapacity(test.storageSizeGb, test.capacityRate, test.snapshotSizesGb)
+		if capacity != test.expected {
+			t.Errorf("%s : expected %+v but %+v", test.name, test.expected, capacity)
+		}
+	}
+}
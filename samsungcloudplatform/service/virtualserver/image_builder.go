@@ -0,0 +1,407 @@
+package virtualserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client/virtualserver"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/service/image"
+	tfTags "github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/service/tag"
+	"github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/image2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_image_builder", ResourceImageBuilder())
+}
+
+func ResourceImageBuilder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceImageBuilderCreate,
+		ReadContext:   resourceImageBuilderRead,
+		UpdateContext: resourceImageBuilderUpdate,
+		DeleteContext: resourceImageBuilderDelete,
+		CustomizeDiff: resourceImageBuilderInputsDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"image_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Custom image name. Used only while building, since the image can not be renamed.",
+				ValidateDiagFunc: common.ValidateName3to60AlphaNumericWithSpaceDashUnderscoreStartsWithLowerAlpha,
+			},
+			"image_description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Custom image description.",
+				ValidateDiagFunc: common.ValidateDescriptionMaxlength50,
+			},
+			"base_image_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Image id of the builder server",
+			},
+			"provisioning_script": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Script which provisions the builder server. The script stops at the first failed command, and the builder server is stopped only if the script succeeds. The image is captured after the builder server is stopped.",
+			},
+			"provisioning_timeout_minutes": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          60,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "Minutes to wait for the builder server to be stopped after it is running. The build fails if the provisioning script fails or does not finish within the time. It must be less than the create timeout. Used only while building.",
+			},
+			"server_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Server type of the builder server (s1v1m2,..)",
+			},
+			"os_storage_size_gb": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				ValidateDiagFunc: common.ValidateBlockStorageSizeForOS,
+				Description:      "OS(Boot) storage size in gigabytes of the builder server. (At least 100 GB required and size must be multiple of 10)",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values which rebuild the image when they are changed",
+			},
+			"builder_server_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "image-builder",
+				ValidateDiagFunc: common.ValidateName3to28AlphaDashStartsWithLowerCase,
+				Description:      "Name of the temporary builder server. Used only while building.",
+			},
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "VPC id of the builder server. Used only while building.",
+			},
+			"subnet_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Subnet id of the builder server. Used only while building.",
+			},
+			"security_group_ids": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Security-Group ids of the builder server. Used only while building.",
+			},
+			"nat_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable NAT of the builder server, so that the provisioning script can access the internet. Used only while building.",
+			},
+			"admin_account": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: common.ValidateName3to20DashUnderscore,
+				Description:      "Admin account of the builder server. For linux, 'root' is used. For Windows, this must not be 'administrator'. Used only while building.",
+			},
+			"admin_password": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				ValidateDiagFunc: common.ValidatePassword8to20,
+				Description:      "Admin account password of the builder server. Used only while building.",
+			},
+			"key_pair_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Key Pair Id of the builder server. Used only while building.",
+			},
+			"tags":              tfTags.TagsSchema(),
+			"build_inputs_hash": {Type: schema.TypeString, Computed: true, Description: "Hash of the build inputs. The image is rebuilt when it is changed."},
+			"image_id":          {Type: schema.TypeString, Computed: true, Description: "Custom image id"},
+			"image_state":       {Type: schema.TypeString, Computed: true, Description: "Image state (ACTIVE)"},
+			"os_type":           {Type: schema.TypeString, Computed: true, Description: "OS type (Windows, Ubuntu, ..)"},
+			"origin_image_id":   {Type: schema.TypeString, Computed: true},
+			"product_group_id":  {Type: schema.TypeString, Computed: true},
+			"service_zone_id":   {Type: schema.TypeString, Computed: true},
+			"created_dt":        {Type: schema.TypeString, Computed: true},
+		},
+		Description: "Builds a Custom Image from a temporary virtual server which is provisioned by a script. The temporary virtual server is deleted after the image is captured.",
+	}
+}
+
+func resourceImageBuilderInputsDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	inputs := make(map[string]interface{})
+	for _, key := range imageBuilderInputKeys {
+		if !diff.NewValueKnown(key) {
+			if len(diff.Id()) == 0 {
+				return diff.SetNewComputed("build_inputs_hash")
+			}
+			if err := diff.SetNewComputed("build_inputs_hash"); err != nil {
+				return err
+			}
+			return diff.ForceNew("build_inputs_hash")
+		}
+		inputs[key] = diff.Get(key)
+	}
+
+	hash, err := getImageBuilderInputsHash(inputs)
+	if err != nil {
+		return err
+	}
+
+	oldHash, _ := diff.GetChange("build_inputs_hash")
+	if oldHash.(string) == hash {
+		return nil
+	}
+	if err = diff.SetNew("build_inputs_hash", hash); err != nil {
+		return err
+	}
+	if len(diff.Id()) == 0 {
+		return nil
+	}
+	return diff.ForceNew("build_inputs_hash")
+}
+
+func resourceImageBuilderCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
+	var err error = nil
+	defer func() {
+		if err != nil {
+			diagnostics = diag.FromErr(err)
+		}
+	}()
+
+	inst := meta.(*client.Instance)
+
+	baseImageId := rd.Get("base_image_id").(string)
+	serverType := rd.Get("server_type").(string)
+	builderServerName := rd.Get("builder_server_name").(string)
+	adminAccount := rd.Get("admin_account").(string)
+	adminPassword := rd.Get("admin_password").(string)
+	keyPairId := rd.Get("key_pair_id").(string)
+
+	if adminPassword == "" && keyPairId == "" {
+		return diag.Errorf("Either admin_password or key_pair_id must be specified.")
+	}
+
+	// the builder server is created and the image is captured within the create timeout as well
+	provisioningTimeout := time.Duration(rd.Get("provisioning_timeout_minutes").(int)) * time.Minute
+	if provisioningTimeout >= rd.Timeout(schema.TimeoutCreate) {
+		return diag.Errorf("provisioning_timeout_minutes must be less than the create timeout %s.", rd.Timeout(schema.TimeoutCreate))
+	}
+
+	vpcInfo, _, err := inst.Client.Vpc.GetVpcInfo(ctx, rd.Get("vpc_id").(string))
+	if err != nil {
+		return
+	}
+
+	isOsWindows, _, targetProductGroupId, err := getImageInfo(ctx, vpcInfo.ServiceZoneId, baseImageId, meta)
+	if err != nil {
+		return
+	}
+	if len(targetProductGroupId) == 0 {
+		return diag.Errorf("Product group id not found from image")
+	}
+
+	if keyPairId == "" {
+		if !isOsWindows {
+			adminAccount = common.LinuxAdminAccount
+		} else if adminAccount == common.WindowsAdminAccount || len(adminAccount) < 5 {
+			return diag.Errorf("Windows admin account must be 5 to 20 alpha-numeric characters with special character and not be 'administrator'.")
+		}
+	}
+
+	productGroup, err := inst.Client.Product.GetProductGroup(ctx, targetProductGroupId)
+	if err != nil {
+		return
+	}
+
+	osDiskProductInfo, err := common.FirstProductId(common.ProductDisk, &productGroup)
+	if err != nil {
+		return
+	}
+
+	scaleProductInfo := getScaleProductInfoFromProductGroupByServerType(productGroup, serverType)
+	if scaleProductInfo == nil {
+		return diag.Errorf("No matched product scale server_type")
+	}
+
+	initialScriptShell := "bash"
+	if isOsWindows {
+		initialScriptShell = "pwsh"
+	}
+
+	createResponse, err := inst.Client.VirtualServer.CreateVirtualServer(ctx, virtualserver.CreateRequest{
+		BlockStorage: virtualserver.BlockStorageInfo{
+			BlockStorageName: builderServerName,
+			DiskSize:         int32(rd.Get("os_storage_size_gb").(int)),
+			DiskType:         osDiskProductInfo.ProductName,
+		},
+		ContractDiscount: "None",
+		ImageId:          baseImageId,
+		InitialScript: virtualserver.InitialScriptInfo{
+			EncodingType:         "plain",
+			InitialScriptContent: getImageBuilderScript(rd.Get("provisioning_script").(string), isOsWindows),
+			InitialScriptShell:   initialScriptShell,
+			InitialScriptType:    "text",
+		},
+		LocalSubnet: virtualserver.LocalSubnetInfo{},
+		Nic: virtualserver.NicInfo{
+			NatEnabled: rd.Get("nat_enabled").(bool),
+			SubnetId:   rd.Get("subnet_id").(string),
+		},
+		OsAdmin: virtualserver.OsAdminInfo{
+			OsUserId:       adminAccount,
+			OsUserPassword: adminPassword,
+		},
+		SecurityGroupIds:  getSecurityGroupIds(rd),
+		ServerType:        scaleProductInfo.ProductName,
+		ServiceZoneId:     vpcInfo.ServiceZoneId,
+		VirtualServerName: builderServerName,
+		Tags:              map[string]interface{}{},
+		KeyPairId:         keyPairId,
+	})
+	if err != nil {
+		return
+	}
+
+	// the builder server is temporary, so it is deleted even if the build fails
+	builderServerId := createResponse.ResourceId
+	defer func() {
+		deleteErr := deleteVirtualServer(ctx, inst, builderServerId)
+		if err == nil && deleteErr != nil {
+			err = fmt.Errorf("failed to delete the builder server %s : %s", builderServerId, deleteErr)
+		}
+	}()
+
+	// the builder server is stopped by the shutdown command at the end of the provisioning script
+	err = waitForImageBuilderServerStopped(ctx, inst, builderServerId, provisioningTimeout, rd.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return
+	}
+
+	imageResponse, _, err := inst.Client.CustomImage.CreateCustomImage(ctx, image2.CustomImageCreateRequest{
+		ImageName:        rd.Get("image_name").(string),
+		VirtualServerId:  builderServerId,
+		ImageDescription: rd.Get("image_description").(string),
+	}, rd.Get("tags").(map[string]interface{}))
+	if err != nil {
+		return
+	}
+	rd.SetId(imageResponse.ResourceId)
+
+	err = image.WaitForCustomImageStatus(ctx, inst.Client, imageResponse.ResourceId, []string{}, []string{image.ActiveState}, true)
+	if err != nil {
+		return
+	}
+
+	return resourceImageBuilderRead(ctx, rd, meta)
+}
+
+// waitForImageBuilderServerStopped waits until the provisioning script stops the builder server.
+// A failed script leaves the builder server running, so the wait fails when the server keeps running for provisioningTimeout.
+func waitForImageBuilderServerStopped(ctx context.Context, inst *client.Instance, builderServerId string, provisioningTimeout time.Duration, timeout time.Duration) error {
+	var runningSince time.Time
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{common.CreatingState, common.EditingState, common.StartingState, common.RunningState, common.StoppingState},
+		Target:  []string{common.StoppedState},
+		Refresh: func() (interface{}, string, error) {
+			info, _, err := inst.Client.VirtualServer.GetVirtualServer(ctx, builderServerId)
+			if err != nil {
+				return nil, "", err
+			}
+			if info.VirtualServerState != common.RunningState {
+				return info, info.VirtualServerState, nil
+			}
+			if runningSince.IsZero() {
+				runningSince = time.Now()
+			}
+			if time.Since(runningSince) > provisioningTimeout {
+				return nil, "", fmt.Errorf("builder server %s is still running after %s. the provisioning script failed or did not finish", builderServerId, provisioningTimeout)
+			}
+			return info, info.VirtualServerState, nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("Error waiting : %s", err)
+	}
+	return nil
+}
+
+func resourceImageBuilderRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	imageInfo, _, err := inst.Client.CustomImage.GetCustomImage(ctx, rd.Id())
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// image_name is not read, since a changed name is used only by the next build
+	rd.Set("image_description", imageInfo.ImageDescription)
+	rd.Set("image_id", imageInfo.ImageId)
+	rd.Set("image_state", imageInfo.ImageState)
+	rd.Set("os_type", imageInfo.OsType)
+	rd.Set("origin_image_id", imageInfo.OriginImageId)
+	rd.Set("product_group_id", imageInfo.ProductGroupId)
+	rd.Set("service_zone_id", imageInfo.ServiceZoneId)
+	rd.Set("created_dt", imageInfo.CreatedDt.String())
+
+	tfTags.SetTags(ctx, rd, meta, rd.Id())
+
+	return nil
+}
+
+func resourceImageBuilderUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	// the other attributes are used only while building, so the image is not changed
+	if rd.HasChanges("image_description") {
+		_, err := inst.Client.CustomImage.UpdateCustomImageDescription(ctx, rd.Id(), rd.Get("image_description").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err := tfTags.UpdateTags(ctx, rd, meta, rd.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceImageBuilderRead(ctx, rd, meta)
+}
+
+func resourceImageBuilderDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	err := inst.Client.CustomImage.DeleteCustomImage(ctx, rd.Id())
+	if err != nil && !common.IsDeleted(err) {
+		return diag.FromErr(err)
+	}
+
+	err = image.WaitForCustomImageStatus(ctx, inst.Client, rd.Id(), []string{}, []string{common.DeletedState}, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
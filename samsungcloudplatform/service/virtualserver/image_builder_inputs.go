@@ -0,0 +1,41 @@
+package virtualserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// imageBuilderInputKeys are the attributes which change the built image. The image is rebuilt only when one of them is changed.
+var imageBuilderInputKeys = []string{
+	"base_image_id",
+	"provisioning_script",
+	"server_type",
+	"os_storage_size_gb",
+	"triggers",
+}
+
+// getImageBuilderInputsHash returns the sha256 hash of the build inputs. The keys of the inputs are sorted by json.Marshal.
+func getImageBuilderInputsHash(inputs map[string]interface{}) (string, error) {
+	bytes, err := json.Marshal(inputs)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// getImageBuilderScript makes the provisioning script stop at the first failed command, and appends the shutdown command to it.
+// The builder server is stopped only when the provisioning succeeds, which is the signal to capture the image.
+func getImageBuilderScript(provisioningScript string, isOsWindows bool) string {
+	failFastCommand, shutdownCommand := "set -e", "shutdown -h now"
+	if isOsWindows {
+		// native commands are stopped by their exit code from PowerShell 7.3
+		failFastCommand, shutdownCommand = "$ErrorActionPreference = 'Stop'\n$PSNativeCommandUseErrorActionPreference = $true", "Stop-Computer -Force"
+	}
+	if len(provisioningScript) != 0 && !strings.HasSuffix(provisioningScript, "\n") {
+		provisioningScript += "\n"
+	}
+	return failFastCommand + "\n" + provisioningScript + shutdownCommand + "\n"
+}
@@ -0,0 +1,55 @@
+package virtualserver
+
+import "testing"
+
+func TestGetImageBuilderInputsHash(t *testing.T) {
+	inputs := map[string]interface{}{
+		"base_image_id":       "IMAGE-1",
+		"provisioning_script": "apt-get install -y nginx",
+		"server_type":         "s1v2m4",
+		"os_storage_size_gb":  100,
+		"triggers":            map[string]interface{}{"version": "1"},
+	}
+	hash, err := getImageBuilderInputsHash(inputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hash) != 64 {
+		t.Errorf("unexpected hash length %d", len(hash))
+	}
+
+	sameHash, _ := getImageBuilderInputsHash(map[string]interface{}{
+		"triggers":            map[string]interface{}{"version": "1"},
+		"os_storage_size_gb":  100,
+		"server_type":         "s1v2m4",
+		"provisioning_script": "apt-get install -y nginx",
+		"base_image_id":       "IMAGE-1",
+	})
+	if hash != sameHash {
+		t.Error("hash should not depend on the order of the inputs")
+	}
+
+	inputs["triggers"] = map[string]interface{}{"version": "2"}
+	changedHash, _ := getImageBuilderInputsHash(inputs)
+	if hash == changedHash {
+		t.Error("hash should be changed with the inputs")
+	}
+}
+
+func TestGetImageBuilderScript(t *testing.T) {
+	testCases := []struct {
+		script      string
+		isOsWindows bool
+		expected    string
+	}{
+		{"apt-get update", false, "set -e\napt-get update\nshutdown -h now\n"},
+		{"apt-get update\n", false, "set -e\napt-get update\nshutdown -h now\n"},
+		{"", false, "set -e\nshutdown -h now\n"},
+		{"Install-WindowsFeature Web-Server", true, "$ErrorActionPreference = 'Stop'\n$PSNativeCommandUseErrorActionPreference = $true\nInstall-WindowsFeature Web-Server\nStop-Computer -Force\n"},
+	}
+	for _, tc := range testCases {
+		if script := getImageBuilderScript(tc.script, tc.isOsWindows); script != tc.expected {
+			t.Errorf("expected %q but %q", tc.expected, script)
+		}
+	}
+}
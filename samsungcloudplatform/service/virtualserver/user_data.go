@@ -0,0 +1,170 @@
+package virtualserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+const (
+	userDataCloudConfig = "text/cloud-config"
+	userDataShellScript = "text/x-shellscript"
+	userDataPowerShell  = "text/x-powershell"
+
+	// userDataMaxBytes is the limit of initialScriptContent documented in the Virtual Server API reference,
+	// which applies to the content after base64 encoding
+	userDataMaxBytes = 64 * 1024
+
+	userDataMimeBoundary = "MIMEBOUNDARY"
+)
+
+type userDataPart struct {
+	ContentType string
+	Content     string
+	Filename    string
+}
+
+type renderedUserData struct {
+	Content      string
+	EncodingType string
+	Shell        string
+}
+
+func expandUserDataParts(list []interface{}) []userDataPart {
+	var parts []userDataPart
+	for _, item := range list {
+		itemObject := item.(map[string]interface{})
+		parts = append(parts, userDataPart{
+			ContentType: itemObject["content_type"].(string),
+			Content:     itemObject["content"].(string),
+			Filename:    itemObject["filename"].(string),
+		})
+	}
+	return parts
+}
+
+// checkUserDataParts checks that the content types of the parts can be combined.
+// PowerShell is only for Windows images and can not be combined with the other parts.
+func checkUserDataParts(parts []userDataPart, isOsWindows bool) error {
+	if len(parts) == 0 {
+		return fmt.Errorf("user_data must have at least one part")
+	}
+	for i, part := range parts {
+		if len(strings.TrimSpace(part.Content)) == 0 {
+			return fmt.Errorf("content of user_data part %d is empty", i+1)
+		}
+		if part.ContentType == userDataPowerShell && len(parts) > 1 {
+			return fmt.Errorf("%s part can not be combined with other parts", userDataPowerShell)
+		}
+		if isOsWindows && part.ContentType != userDataPowerShell {
+			return fmt.Errorf("only %s is supported for Windows images, but part %d is %s", userDataPowerShell, i+1, part.ContentType)
+		}
+		if !isOsWindows && part.ContentType == userDataPowerShell {
+			return fmt.Errorf("%s is only supported for Windows images", userDataPowerShell)
+		}
+	}
+	return nil
+}
+
+// combineUserDataParts returns the content of a single part as it is, and combines several parts into a multipart MIME document
+func combineUserDataParts(parts []userDataPart) (string, error) {
+	if len(parts) == 1 {
+		return parts[0].Content, nil
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.SetBoundary(userDataMimeBoundary); err != nil {
+		return "", err
+	}
+	for i, part := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.ContentType+"; charset=\"utf-8\"")
+		header.Set("Content-Transfer-Encoding", getUserDataTransferEncoding(part.Content))
+		filename := part.Filename
+		if len(filename) == 0 {
+			filename = fmt.Sprintf("part-%03d", i+1)
+		}
+		header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return "", err
+		}
+		if _, err := partWriter.Write([]byte(part.Content)); err != nil {
+			return "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q\r\nMIME-Version: 1.0\r\n\r\n", userDataMimeBoundary) + body.String(), nil
+}
+
+// getUserDataTransferEncoding returns 7bit for ASCII content, and 8bit for the other content such as UTF-8 text
+func getUserDataTransferEncoding(content string) string {
+	for i := 0; i < len(content); i++ {
+		if content[i] >= 0x80 {
+			return "8bit"
+		}
+	}
+	return "7bit"
+}
+
+// encodeUserData combines the parts and encodes them to base64 (after gzip if useGzip is true).
+// It does not depend on the image, so the size can be checked on plan before the image is looked up.
+func encodeUserData(parts []userDataPart, useGzip bool) (string, error) {
+	content, err := combineUserDataParts(parts)
+	if err != nil {
+		return "", err
+	}
+
+	data := []byte(content)
+	if useGzip {
+		var compressed bytes.Buffer
+		gzipWriter := gzip.NewWriter(&compressed)
+		if _, err := gzipWriter.Write(data); err != nil {
+			return "", err
+		}
+		if err := gzipWriter.Close(); err != nil {
+			return "", err
+		}
+		data = compressed.Bytes()
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	if len(encoded) > userDataMaxBytes {
+		return "", fmt.Errorf("encoded user_data is %d bytes, which exceeds the limit of %d bytes", len(encoded), userDataMaxBytes)
+	}
+	return encoded, nil
+}
+
+// renderUserData checks the parts with the OS of the image and encodes them
+func renderUserData(parts []userDataPart, useGzip bool, isOsWindows bool) (renderedUserData, error) {
+	if err := checkUserDataParts(parts, isOsWindows); err != nil {
+		return renderedUserData{}, err
+	}
+	if useGzip && isOsWindows {
+		return renderedUserData{}, fmt.Errorf("gzip is not supported for Windows images")
+	}
+
+	encoded, err := encodeUserData(parts, useGzip)
+	if err != nil {
+		return renderedUserData{}, err
+	}
+
+	shell := "bash"
+	if isOsWindows {
+		shell = "pwsh"
+	}
+	return renderedUserData{
+		Content:      encoded,
+		EncodingType: "base64",
+		Shell:        shell,
+	}, nil
+}
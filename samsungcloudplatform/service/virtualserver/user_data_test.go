@@ -0,0 +1,116 @@
+package virtualserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+func TestRenderUserDataSinglePart(t *testing.T) {
+	parts := []userDataPart{{ContentType: userDataCloudConfig, Content: "#cloud-config\npackages:\n  - nginx\n"}}
+
+	rendered, err := renderUserData(parts, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendered.EncodingType != "base64" || rendered.Shell != "bash" {
+		t.Errorf("unexpected encoding %s or shell %s", rendered.EncodingType, rendered.Shell)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(rendered.Content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decoded) != parts[0].Content {
+		t.Errorf("unexpected content %q", decoded)
+	}
+}
+
+func TestRenderUserDataMultipartGzip(t *testing.T) {
+	parts := []userDataPart{
+		{ContentType: userDataCloudConfig, Content: "#cloud-config\nruncmd:\n  - echo hello\n", Filename: "init.yaml"},
+		{ContentType: userDataShellScript, Content: "#!/bin/bash\necho 세계\n"},
+	}
+
+	rendered, err := renderUserData(parts, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed, err := base64.StdEncoding.DecodeString(rendered.Content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	document, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, body, found := strings.Cut(string(document), "\r\n\r\n")
+	if !found {
+		t.Fatalf("MIME header not found in %q", document)
+	}
+	mediaType, params, err := mime.ParseMediaType(strings.TrimPrefix(strings.Split(header, "\r\n")[0], "Content-Type: "))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("unexpected content type %s : %v", mediaType, err)
+	}
+
+	partReader := multipart.NewReader(strings.NewReader(body), params["boundary"])
+	var contentTypes, filenames, encodings []string
+	for {
+		part, err := partReader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		contentTypes = append(contentTypes, contentType)
+		encodings = append(encodings, part.Header.Get("Content-Transfer-Encoding"))
+		filenames = append(filenames, part.FileName())
+	}
+	if strings.Join(contentTypes, ",") != userDataCloudConfig+","+userDataShellScript {
+		t.Errorf("unexpected content types %v", contentTypes)
+	}
+	if strings.Join(filenames, ",") != "init.yaml,part-002" {
+		t.Errorf("unexpected filenames %v", filenames)
+	}
+	if strings.Join(encodings, ",") != "7bit,8bit" {
+		t.Errorf("unexpected transfer encodings %v", encodings)
+	}
+}
+
+func TestRenderUserDataErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		parts       []userDataPart
+		useGzip     bool
+		isOsWindows bool
+		expectedErr string
+	}{
+		{"powershell on linux", []userDataPart{{ContentType: userDataPowerShell, Content: "Write-Host hi"}}, false, false, "only supported for Windows"},
+		{"cloud-config on windows", []userDataPart{{ContentType: userDataCloudConfig, Content: "#cloud-config"}}, false, true, "only text/x-powershell"},
+		{"gzip on windows", []userDataPart{{ContentType: userDataPowerShell, Content: "Write-Host hi"}}, true, true, "gzip"},
+		{"powershell combined", []userDataPart{{ContentType: userDataPowerShell, Content: "Write-Host hi"}, {ContentType: userDataPowerShell, Content: "Write-Host hi"}}, false, true, "can not be combined"},
+		{"empty part", []userDataPart{{ContentType: userDataShellScript, Content: " \n"}}, false, false, "empty"},
+		{"too large", []userDataPart{{ContentType: userDataShellScript, Content: strings.Repeat("echo", userDataMaxBytes)}}, false, false, "exceeds the limit"},
+	}
+	for _, test := range tests {
+		_, err := renderUserData(test.parts, test.useGzip, test.isOsWindows)
+		if err == nil || !strings.Contains(err.Error(), test.expectedErr) {
+			t.Errorf("%s : expected error containing %q, got %v", test.name, test.expectedErr, err)
+		}
+	}
+
+	if _, err := encodeUserData([]userDataPart{{ContentType: userDataShellScript, Content: strings.Repeat("echo", userDataMaxBytes)}}, true); err != nil {
+		t.Errorf("gzip should fit in the limit : %v", err)
+	}
+}
@@ -17,7 +17,9 @@ import (
 	virtualserver2 "github.com/SamsungSDSCloud/terraform-sdk-samsungcloudplatform/v3/library/virtual-server2"
 	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"strconv"
 	"strings"
@@ -47,9 +49,16 @@ func ResourceVirtualServer() *schema.Resource {
 			},
 			"state": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
+				Computed:         true,
 				ValidateDiagFunc: common.ValidateThatVmStateOnlyHasRunningOrStopped,
-				Description:      "Virtual Server State",
+				Description:      "Virtual Server State. Leave it unset when the power state is managed by samsungcloudplatform_virtual_server_power.",
+			},
+			"allow_stopping_for_update": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, a running virtual server is stopped to change server_type, cpu_count, memory_size_gb, contract_discount or security_group_ids, and started again unless state is STOPPED, even if the change fails.",
 			},
 			/*"name_prefix": {
 				Type:             schema.TypeString,
@@ -133,6 +142,18 @@ func ResourceVirtualServer() *schema.Resource {
 				Description: "External block storage.",
 				Elem:        common.ExternalStorageResourceSchema(),
 			},
+			"ignore_external_storage_attachments": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, block storages which are not in external_storage (e.g. attached by samsungcloudplatform_block_storage_attachment) are not read into external_storage, so they are never detached or deleted by the virtual server.",
+			},
+			"ignore_external_local_subnets": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, local subnet network interfaces which are not in local_subnet (e.g. attached by samsungcloudplatform_virtual_server_nic) are not read into local_subnet, so they are never detached by the virtual server.",
+			},
 			"vpc_id": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -150,7 +171,52 @@ func ResourceVirtualServer() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 				Default:     "",
-				Description: "Initialization script",
+				Description: "Initialization script. Use user_data for cloud-init or encoded scripts.",
+			},
+			"user_data": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Initialization data which is encoded to base64. It can not be used with initial_script_content.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"part": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "Parts of the user data. Several parts are combined into a multipart MIME document for cloud-init.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"content_type": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Default:          userDataCloudConfig,
+										Description:      "Content type of the part : text/cloud-config, text/x-shellscript or text/x-powershell (Windows images only)",
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{userDataCloudConfig, userDataShellScript, userDataPowerShell}, false)),
+									},
+									"content": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Content of the part",
+									},
+									"filename": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "",
+										Description: "File name of the part in the multipart MIME document",
+									},
+								},
+							},
+						},
+						"gzip": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Compress the user data with gzip before base64 encoding. (Linux images only)",
+						},
+					},
+				},
 			},
 			"security_group_ids": {
 				Type:     schema.TypeList,
@@ -164,12 +230,14 @@ func ResourceVirtualServer() *schema.Resource {
 			"subnet_id": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Subnet id of this virtual server. Subnet must be a valid subnet resource which is attached to the VPC.",
+				Description: "Subnet id of this virtual server. Subnet must be a valid subnet resource which is attached to the VPC. Changing the subnet forces a new virtual server.",
 			},
 			"internal_ip_address": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "IP address for internal IP assignment.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: common.ValidateIpv4WithEmptyValue,
+				Description:      "IP address for internal IP assignment. Can be changed in place within the same subnet.",
 			},
 			"local_subnet": {
 				Type:        schema.TypeList,
@@ -259,10 +327,58 @@ func ResourceVirtualServer() *schema.Resource {
 				Description: "Role Id",
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourceVirtualServerUserDataDiff,
+			// Moving the primary network interface to another subnet is not supported by the API
+			customdiff.ForceNewIfChange("subnet_id", func(ctx context.Context, oldValue, newValue, meta interface{}) bool {
+				return len(oldValue.(string)) != 0 && oldValue.(string) != newValue.(string)
+			}),
+		),
 		Description: "Provides a Virtual Server resource.",
 	}
 }
 
+// resourceVirtualServerUserDataDiff checks the size of the encoded user data on plan, and the parts with the OS of the image.
+// The image is looked up only when image_id and vpc_id are known, otherwise the parts are checked on create.
+func resourceVirtualServerUserDataDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	userDataList := diff.Get("user_data").([]interface{})
+	if len(userDataList) == 0 || userDataList[0] == nil {
+		return nil
+	}
+	if len(diff.Get("initial_script_content").(string)) != 0 {
+		return fmt.Errorf("initial_script_content and user_data can not be used together")
+	}
+	if len(diff.Id()) != 0 && !diff.HasChange("user_data") {
+		return nil
+	}
+
+	userData := userDataList[0].(map[string]interface{})
+	parts := expandUserDataParts(userData["part"].([]interface{}))
+	for i := range parts {
+		if !diff.NewValueKnown(fmt.Sprintf("user_data.0.part.%d.content", i)) {
+			return nil
+		}
+	}
+	if _, err := encodeUserData(parts, userData["gzip"].(bool)); err != nil {
+		return err
+	}
+
+	if !diff.NewValueKnown("image_id") || !diff.NewValueKnown("vpc_id") {
+		return nil
+	}
+	inst := meta.(*client.Instance)
+	vpcInfo, _, err := inst.Client.Vpc.GetVpcInfo(ctx, diff.Get("vpc_id").(string))
+	if err != nil {
+		return err
+	}
+	isOsWindows, _, _, err := getImageInfo(ctx, vpcInfo.ServiceZoneId, diff.Get("image_id").(string), meta)
+	if err != nil {
+		return err
+	}
+	_, err = renderUserData(parts, userData["gzip"].(bool), isOsWindows)
+	return err
+}
+
 func getSecurityGroupIds(rd *schema.ResourceData) []string {
 	securityGroupIds := rd.Get("security_group_ids").([]interface{})
 	sgIds := make([]string, len(securityGroupIds))
@@ -547,6 +663,16 @@ func resourceVirtualServerCreate(ctx context.Context, rd *schema.ResourceData, m
 		InitialScriptShell:   initialScriptShell,
 		InitialScriptType:    "text",
 	}
+	if userDataList := rd.Get("user_data").([]interface{}); len(userDataList) != 0 && userDataList[0] != nil {
+		userData := userDataList[0].(map[string]interface{})
+		rendered, err := renderUserData(expandUserDataParts(userData["part"].([]interface{})), userData["gzip"].(bool), isOsWindows)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		initialScript.EncodingType = rendered.EncodingType
+		initialScript.InitialScriptContent = rendered.Content
+		initialScript.InitialScriptShell = rendered.Shell
+	}
 
 	createRequest := virtualserver.CreateRequest{
 		BlockStorage: virtualserver.BlockStorageInfo{
@@ -874,7 +1000,10 @@ func resourceVirtualServerRead(ctx context.Context, rd *schema.ResourceData, met
 	}
 	rd.Set("vpc_id", virtualServerInfo.VpcId)
 	rd.Set("use_dns", virtualServerInfo.DnsEnabled)
-	rd.Set("initial_script_content", virtualServerInfo.InitialScriptContent)
+	// the encoded user data is returned as the initial script content
+	if len(rd.Get("user_data").([]interface{})) == 0 {
+		rd.Set("initial_script_content", virtualServerInfo.InitialScriptContent)
+	}
 
 	sgIds := common.HclListObject{}
 	for _, sg := range virtualServerInfo.SecurityGroupIds {
@@ -936,7 +1065,7 @@ func resourceVirtualServerRead(ctx context.Context, rd *schema.ResourceData, met
 	}
 
 	for _, blockStorageResponse := range blockStorageResponseList {
-		if *blockStorageResponse.IsBootDisk {
+		if *blockStorageResponse.IsBootDisk || rd.Get("ignore_external_storage_attachments").(bool) {
 			continue
 		}
 		var idx int
@@ -1003,6 +1132,7 @@ func resourceVirtualServerRead(ctx context.Context, rd *schema.ResourceData, met
 	//var localSubnetId string
 	//var localSubnetIpv4 string
 	var subnetId string
+	var internalIpAddress string
 	//var subnetIpv4 string
 	var natIpv4 string
 	//var ipv4 string
@@ -1020,9 +1150,11 @@ func resourceVirtualServerRead(ctx context.Context, rd *schema.ResourceData, met
 					})
 				} else if nic.SubnetType == "PUBLIC" {
 					subnetId = nic.SubnetId
+					internalIpAddress = nic.Ip
 					natIpv4 = nic.NatIp
 				} else {
 					subnetId = nic.SubnetId
+					internalIpAddress = nic.Ip
 				}
 				break
 			}
@@ -1031,7 +1163,8 @@ func resourceVirtualServerRead(ctx context.Context, rd *schema.ResourceData, met
 
 	rd.Set("ipv4", ipv4)
 	rd.Set("subnet_id", subnetId)
-	rd.Set("local_subnet", localSubnetInfos)
+	rd.Set("internal_ip_address", internalIpAddress)
+	rd.Set("local_subnet", filterManagedLocalSubnets(rd, localSubnetInfos))
 	rd.Set("nat_ipv4", natIpv4)
 
 	if natIpv4 != "" {
@@ -1110,6 +1243,9 @@ func getBlockStorageResponseList(ctx context.Context, blockStorageIds []string,
 	return blockStorageResponseList
 }
 
+// virtualServerStopForUpdateKeys are changed while the virtual server is stopped, if allow_stopping_for_update is true
+var virtualServerStopForUpdateKeys = []string{"cpu_count", "memory_size_gb", "server_type", "contract_discount", "security_group_ids"}
+
 func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) (diagnostics diag.Diagnostics) {
 	var err error = nil
 	defer func() {
@@ -1127,6 +1263,44 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 
 	targetProductGroupId := virtualServerInfo.ProductGroupId
 
+	// the virtual server stays in activeStates after each change
+	activeStates := []string{common.RunningState}
+	if virtualServerInfo.VirtualServerState == common.StoppedState {
+		activeStates = []string{common.StoppedState}
+	}
+
+	stoppedForUpdate := false
+	if rd.HasChanges(virtualServerStopForUpdateKeys...) && virtualServerInfo.VirtualServerState == common.RunningState {
+		if !rd.Get("allow_stopping_for_update").(bool) {
+			log.Printf("[WARN] virtual server %s is running. set allow_stopping_for_update if the change requires the virtual server stopped", rd.Id())
+		} else {
+			err = changeVirtualServerPowerState(ctx, inst, rd.Id(), common.StoppedState)
+			if err != nil {
+				return
+			}
+			activeStates = []string{common.StoppedState}
+			stoppedForUpdate = true
+		}
+	}
+
+	// the virtual server stopped for the update is started again even if the update fails, unless the target state is STOPPED
+	restartPending := stoppedForUpdate && strings.ToUpper(rd.Get("state").(string)) != common.StoppedState
+	defer func() {
+		if !restartPending {
+			return
+		}
+		restartErr := changeVirtualServerPowerState(ctx, inst, rd.Id(), common.RunningState)
+		if restartErr == nil {
+			return
+		}
+		// err is converted to diagnostics by the first deferred function, which runs after this one
+		if err != nil {
+			err = fmt.Errorf("%v. starting the virtual server stopped for the update also failed: %v", err, restartErr)
+		} else {
+			diagnostics = append(diagnostics, diag.FromErr(restartErr)...)
+		}
+	}()
+
 	if rd.HasChanges("cpu_count", "memory_size_gb") {
 		cpuCount := rd.Get("cpu_count").(int)
 		memorySizeGB := rd.Get("memory_size_gb").(int)
@@ -1147,7 +1321,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 			return
 		}
 
-		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 		if err != nil {
 			return
 		}
@@ -1170,7 +1344,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 			return
 		}
 
-		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 		if err != nil {
 			return
 		}
@@ -1182,7 +1356,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 		if err != nil {
 			return
 		}
-		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 		if err != nil {
 			return
 		}
@@ -1209,7 +1383,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 			if err != nil {
 				continue
 			}
-			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 			if err != nil {
 				return
 			}
@@ -1224,7 +1398,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 			if err != nil {
 				return
 			}
-			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 			if err != nil {
 				return
 			}
@@ -1296,7 +1470,11 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 				}
 			} else if mi.Trigger < 0 {
 				// Detach
-				if nicId, ok := mapSubnetId2NicId[mi.SubnetId]; ok {
+				nicId, ok := mi.NicId, len(mi.NicId) != 0
+				if !ok {
+					nicId, ok = mapSubnetId2NicId[mi.SubnetId]
+				}
+				if ok {
 					_, _, err = inst.Client.VirtualServer.DetachLocalSubnet(ctx, virtualServerInfo.VirtualServerId, nicId)
 					if err != nil {
 						return
@@ -1309,7 +1487,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 				// No-op
 				continue
 			}
-			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 			if err != nil {
 				return
 			}
@@ -1366,13 +1544,14 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 			}
 		}
 
-		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 		if err != nil {
 			return
 		}
 	}
 
-	if rd.HasChanges("internal_ip_address") || rd.HasChanges("subnet_id") {
+	// Subnet change forces a new resource, so only the internal ip address changes within the same subnet here
+	if rd.HasChanges("internal_ip_address") {
 		newSubnetId := rd.Get("subnet_id").(string)
 		newInternalIpAddress := rd.Get("internal_ip_address").(string)
 		if len(newInternalIpAddress) != 0 {
@@ -1391,7 +1570,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 		if err != nil {
 			return
 		}
@@ -1411,7 +1590,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 		} else {
 			return diag.Errorf("Once the Contract Discount created, it can be changed after the contract discount period expires.")
 		}
-		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 		if err != nil {
 			return
 		}
@@ -1424,10 +1603,19 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
+		if err != nil {
+			return
+		}
+	}
+
+	if restartPending {
+		restartPending = false
+		err = changeVirtualServerPowerState(ctx, inst, rd.Id(), common.RunningState)
 		if err != nil {
 			return
 		}
+		activeStates = []string{common.RunningState}
 	}
 
 	if rd.HasChanges("os_storage_size_gb") {
@@ -1441,7 +1629,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 					BlockStorageId:   blockStorageResponse.BlockStorageId,
 					BlockStorageSize: int32(osStorageSize),
 				})
-				err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+				err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 				if err != nil {
 					return
 				}
@@ -1474,7 +1662,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 				BlockStorageId:   externalStorageWithChangeSize.BlockStorageId,
 				BlockStorageSize: externalStorageWithChangeSize.StorageSizeGb,
 			})
-			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 			if err != nil {
 				return
 			}
@@ -1491,7 +1679,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 			if err != nil {
 				return
 			}
@@ -1510,14 +1698,14 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 					return diag.FromErr(err)
 				}
 			}
-			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+			err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 			if err != nil {
 				return
 			}
 		}
 	}
 
-	if rd.HasChanges("state") {
+	if rd.HasChanges("state") && !stoppedForUpdate {
 		var VmState string
 		if strings.Compare(strings.ToUpper(rd.Get("state").(string)), "STOPPED") == 0 {
 			_, err = inst.Client.VirtualServer.StopVirtualServer(ctx, rd.Id())
@@ -1538,6 +1726,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 		if err != nil {
 			return
 		}
+		activeStates = []string{VmState}
 	}
 
 	if rd.HasChanges("role_id") {
@@ -1550,7 +1739,7 @@ func resourceVirtualServerUpdate(ctx context.Context, rd *schema.ResourceData, m
 		if err != nil {
 			return
 		}
-		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState}, true)
+		err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), activeStates, true)
 		if err != nil {
 			return
 		}
@@ -1735,20 +1924,51 @@ func getExternalStorageStructArray(mapExternalStorages []map[string]interface{})
 //	return oldInternalIpAddress, newInternalIpAddress
 //}
 
-func resourceVirtualServerDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+// filterManagedLocalSubnets keeps the local subnet interfaces managed by this resource only, if ignore_external_local_subnets is set.
+// The interfaces in local_subnet are matched by network interface id. An interface which is not attached yet claims one
+// unmatched network interface of its subnet, so the interfaces attached by samsungcloudplatform_virtual_server_nic are not pulled in.
+func filterManagedLocalSubnets(rd *schema.ResourceData, localSubnetInfos []common.HclKeyValueObject) []common.HclKeyValueObject {
+	if !rd.Get("ignore_external_local_subnets").(bool) {
+		return localSubnetInfos
+	}
 
-	inst := meta.(*client.Instance)
-	error := WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState, common.ErrorState}, false)
-	if error != nil {
-		return diag.FromErr(error)
+	claimed := make(map[string]bool)
+	var pending []map[string]interface{}
+	for _, item := range rd.Get("local_subnet").([]interface{}) {
+		itemObject := item.(map[string]interface{})
+		if nicId, ok := itemObject["id"].(string); ok && len(nicId) != 0 {
+			claimed[nicId] = true
+		} else {
+			pending = append(pending, itemObject)
+		}
+	}
+	for _, itemObject := range pending {
+		for _, info := range localSubnetInfos {
+			nicId := info["id"].(string)
+			if claimed[nicId] || info["subnet_id"] != itemObject["subnet_id"] {
+				continue
+			}
+			if ipv4, ok := itemObject["ipv4"].(string); ok && len(ipv4) != 0 && info["ipv4"] != ipv4 {
+				continue
+			}
+			claimed[nicId] = true
+			break
+		}
 	}
 
-	_, err := inst.Client.VirtualServer.DeleteVirtualServer(ctx, rd.Id())
-	if err != nil && !common.IsDeleted(err) {
-		return diag.FromErr(err)
+	var result []common.HclKeyValueObject
+	for _, info := range localSubnetInfos {
+		if claimed[info["id"].(string)] {
+			result = append(result, info)
+		}
 	}
+	return result
+}
 
-	err = WaitForVirtualServerStatus(ctx, inst.Client, rd.Id(), common.VirtualServerProcessingStates(), []string{common.DeletedState}, false)
+func resourceVirtualServerDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	inst := meta.(*client.Instance)
+	err := deleteVirtualServer(ctx, inst, rd.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -1756,6 +1976,20 @@ func resourceVirtualServerDelete(ctx context.Context, rd *schema.ResourceData, m
 	return nil
 }
 
+func deleteVirtualServer(ctx context.Context, inst *client.Instance, virtualServerId string) error {
+	err := WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState, common.ErrorState}, false)
+	if err != nil {
+		return err
+	}
+
+	_, err = inst.Client.VirtualServer.DeleteVirtualServer(ctx, virtualServerId)
+	if err != nil && !common.IsDeleted(err) {
+		return err
+	}
+
+	return WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.DeletedState}, false)
+}
+
 func WaitForVirtualServerStatus(ctx context.Context, scpClient *client.SCPClient, id string, pendingStates []string, targetStates []string, errorOnNotFound bool) error {
 	return client.WaitForStatus(ctx, scpClient, pendingStates, targetStates, func() (interface{}, string, error) {
 		info, c, err := scpClient.VirtualServer.GetVirtualServer(ctx, id)
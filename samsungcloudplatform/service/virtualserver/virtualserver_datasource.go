@@ -24,19 +24,24 @@ func DatasourceVirtualServer() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
-			"filter":                common.DatasourceFilter(),
-			"virtual_server_id":     {Type: schema.TypeString, Optional: true, Description: "Virtual server id"},
-			"virtual_server_name":   {Type: schema.TypeString, Optional: true, Description: "Virtual Server Name"},
-			"auto_scaling_enabled":  {Type: schema.TypeBool, Optional: true, Description: "Auto Scaling Enabled"},
-			"server_group_id":       {Type: schema.TypeString, Optional: true, Description: "Server Group Id"},
-			"auto_scaling_group_id": {Type: schema.TypeString, Optional: true, Description: "Auto Scaling Group Id"},
-			//"serviced_for_list":       {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString, Description: "Serviced For"}, Description: "Serviced For List"},
-			//"serviced_group_for_list": {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString, Description: "Serviced Group For"}, Description: "Serviced Group For List"},
-			"page":        {Type: schema.TypeInt, Optional: true, Default: 0, Description: "Page start number from which to get the list"},
-			"size":        {Type: schema.TypeInt, Optional: true, Default: 20, Description: "Size to get list"},
-			"sort":        {Type: schema.TypeString, Optional: true, Description: "Sort"},
-			"contents":    {Type: schema.TypeList, Computed: true, Description: "Virtual Server list", Elem: datasourceElem()},
-			"total_count": {Type: schema.TypeInt, Computed: true, Description: "Total list size"},
+			"filter":                  common.DatasourceFilter(),
+			"virtual_server_id":       {Type: schema.TypeString, Optional: true, Description: "Virtual server id"},
+			"virtual_server_name":     {Type: schema.TypeString, Optional: true, Description: "Virtual Server Name"},
+			"auto_scaling_enabled":    {Type: schema.TypeBool, Optional: true, Description: "Auto Scaling Enabled"},
+			"server_group_id":         {Type: schema.TypeString, Optional: true, Description: "Server Group Id"},
+			"auto_scaling_group_id":   {Type: schema.TypeString, Optional: true, Description: "Auto Scaling Group Id"},
+			"serviced_for_list":       {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString, Description: "Serviced For"}, Description: "Serviced For List"},
+			"serviced_group_for_list": {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString, Description: "Serviced Group For"}, Description: "Serviced Group For List"},
+			"virtual_server_state":    {Type: schema.TypeString, Optional: true, Description: "Virtual Server State (e.g. RUNNING, STOPPED)"},
+			"vpc_id":                  {Type: schema.TypeString, Optional: true, Description: "Vpc Id"},
+			"subnet_id":               {Type: schema.TypeString, Optional: true, Description: "Subnet Id of any network interface of the virtual server"},
+			"security_group_id":       {Type: schema.TypeString, Optional: true, Description: "Security Group Id attached to the virtual server"},
+			"tags":                    {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}, Description: "Tags which the virtual server must have. An empty value matches any value of the tag key."},
+			"page":                    {Type: schema.TypeInt, Optional: true, Default: 0, Description: "Page start number from which to get the list"},
+			"size":                    {Type: schema.TypeInt, Optional: true, Default: 20, Description: "Size to get list"},
+			"sort":                    {Type: schema.TypeString, Optional: true, Description: "Sort"},
+			"contents":                {Type: schema.TypeList, Computed: true, Description: "Virtual Server list", Elem: datasourceElem()},
+			"total_count":             {Type: schema.TypeInt, Computed: true, Description: "Total list size"},
 		},
 		Description: "Provides list of Virtual Servers",
 	}
@@ -94,6 +99,28 @@ func datasourceElem() *schema.Resource {
 			"created_dt":           {Type: schema.TypeString, Computed: true, Description: "Created Date"},
 			"modified_by":          {Type: schema.TypeString, Computed: true, Description: "Modified By"},
 			"modified_dt":          {Type: schema.TypeString, Computed: true, Description: "Modified Date"},
+			"nics": {Type: schema.TypeList, Computed: true, Description: "Network interfaces",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nic_id":    {Type: schema.TypeString, Computed: true, Description: "Nic Id"},
+						"subnet_id": {Type: schema.TypeString, Computed: true, Description: "Subnet Id"},
+						"ip":        {Type: schema.TypeString, Computed: true, Description: "Ip"},
+						"nat_ip":    {Type: schema.TypeString, Computed: true, Description: "Nat Ip"},
+					},
+				},
+			},
+			"block_storages": {Type: schema.TypeList, Computed: true, Description: "Attached block storages",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"block_storage_id":   {Type: schema.TypeString, Computed: true, Description: "Block Storage Id"},
+						"block_storage_name": {Type: schema.TypeString, Computed: true, Description: "Block Storage Name"},
+						"block_storage_size": {Type: schema.TypeInt, Computed: true, Description: "Storage size(GB)"},
+						"is_boot_disk":       {Type: schema.TypeBool, Computed: true, Description: "Is Boot Disk"},
+						"shared_type":        {Type: schema.TypeString, Computed: true, Description: "Shared Type"},
+					},
+				},
+			},
+			"tags": {Type: schema.TypeMap, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}, Description: "Tags"},
 		},
 		Description: "Virtual Server Element",
 	}
@@ -103,56 +130,106 @@ func dataSourceList(ctx context.Context, rd *schema.ResourceData, meta interface
 
 	inst := meta.(*client.Instance)
 	virtualServerId := rd.Get("virtual_server_id").(string)
+	filter := getVirtualServerListFilter(rd)
 	contents := make([]map[string]interface{}, 0)
 	if strings.Compare(virtualServerId, "") == 0 {
 
-		responses, err := inst.Client.VirtualServer.ListVirtualServers(ctx, *getListVirtualServersRequestParam(rd))
+		virtualServerIds, err := listVirtualServerIds(ctx, inst, rd, filter)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-
-		var diagnostics diag.Diagnostics
-		var done bool
-		for _, response := range responses.Contents {
-			contents, diagnostics, done = appendContentFromDetailVirtualServerApi(contents, ctx, inst, response.VirtualServerId)
-			if done {
-				return diagnostics
+		for _, id := range virtualServerIds {
+			content, matched, err := getFilteredContentFromDetailVirtualServerApi(ctx, inst, id, filter)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if matched {
+				contents = append(contents, content)
 			}
-		}
-		if f, ok := rd.GetOk("filter"); ok {
-			contents = common.ApplyFilter(datasourceElem().Schema, f.(*schema.Set), contents)
 		}
 
-		rd.SetId(uuid.NewV4().String())
-		rd.Set("contents", contents)
-		rd.Set("total_count", len(contents))
-
 	} else {
 		// detail api
-		contents, diagnostics, done := appendContentFromDetailVirtualServerApi(contents, ctx, inst, virtualServerId)
-		if done {
-			return diagnostics
+		content, matched, err := getFilteredContentFromDetailVirtualServerApi(ctx, inst, virtualServerId, filter)
+		if err != nil {
+			return diag.FromErr(err)
 		}
+		if matched {
+			contents = append(contents, content)
+		}
+	}
 
-		rd.SetId(uuid.NewV4().String())
-		rd.Set("contents", contents)
-		rd.Set("total_count", 1)
-
+	if f, ok := rd.GetOk("filter"); ok {
+		contents = common.ApplyFilter(datasourceElem().Schema, f.(*schema.Set), contents)
 	}
+
+	rd.SetId(uuid.NewV4().String())
+	rd.Set("contents", contents)
+	rd.Set("total_count", len(contents))
+
 	return nil
 }
 
+// listVirtualServerIds lists the virtual servers of the requested page. When any filter that the list API does not support
+// is set, every page is listed instead so that matched servers on other pages are not missed.
+func listVirtualServerIds(ctx context.Context, inst *client.Instance, rd *schema.ResourceData, filter virtualServerListFilter) ([]string, error) {
+	request := getListVirtualServersRequestParam(rd)
+	_, hasFilter := rd.GetOk("filter")
+	allPages := !filter.isEmpty() || hasFilter
+	if allPages {
+		request.Page = 0
+		if request.Size <= 0 {
+			request.Size = 20
+		}
+	}
+
+	virtualServerIds := make([]string, 0)
+	listed := 0
+	for {
+		responses, err := inst.Client.VirtualServer.ListVirtualServers(ctx, *request)
+		if err != nil {
+			return nil, err
+		}
+		for _, response := range responses.Contents {
+			if filter.matchesListItem(common.ToMap(response)) {
+				virtualServerIds = append(virtualServerIds, response.VirtualServerId)
+			}
+		}
+		listed += len(responses.Contents)
+
+		if !allPages || len(responses.Contents) == 0 || listed >= int(responses.TotalCount) {
+			break
+		}
+		request.Page++
+	}
+	return virtualServerIds, nil
+}
+
 func getListVirtualServersRequestParam(rd *schema.ResourceData) *virtualserver.ListVirtualServersRequestParam {
 	return &virtualserver.ListVirtualServersRequestParam{
-		AutoscalingEnabled: getBoolPtrFromRd(rd, "auto_scaling_enabled"),
-		ServerGroupId:      rd.Get("server_group_id").(string),
-		VirtualServerName:  rd.Get("virtual_server_name").(string),
-		AutoScalingGroupId: rd.Get("auto_scaling_group_id").(string),
-		//ServicedForList:      convertToStringArray(rd.Get("serviced_for_list").([]interface{})),
-		//ServicedGroupForList: convertToStringArray(rd.Get("serviced_group_for_list").([]interface{})),
-		Page: int32(rd.Get("page").(int)),
-		Size: int32(rd.Get("size").(int)),
-		Sort: rd.Get("sort").(string),
+		AutoscalingEnabled:   getBoolPtrFromRd(rd, "auto_scaling_enabled"),
+		ServerGroupId:        rd.Get("server_group_id").(string),
+		VirtualServerName:    rd.Get("virtual_server_name").(string),
+		AutoScalingGroupId:   rd.Get("auto_scaling_group_id").(string),
+		ServicedForList:      convertToStringArray(rd.Get("serviced_for_list").([]interface{})),
+		ServicedGroupForList: convertToStringArray(rd.Get("serviced_group_for_list").([]interface{})),
+		Page:                 int32(rd.Get("page").(int)),
+		Size:                 int32(rd.Get("size").(int)),
+		Sort:                 rd.Get("sort").(string),
+	}
+}
+
+func getVirtualServerListFilter(rd *schema.ResourceData) virtualServerListFilter {
+	tags := make(map[string]string)
+	for key, value := range rd.Get("tags").(map[string]interface{}) {
+		tags[key] = value.(string)
+	}
+	return virtualServerListFilter{
+		VirtualServerState: strings.ToUpper(rd.Get("virtual_server_state").(string)),
+		VpcId:              rd.Get("vpc_id").(string),
+		SubnetId:           rd.Get("subnet_id").(string),
+		SecurityGroupId:    rd.Get("security_group_id").(string),
+		Tags:               tags,
 	}
 }
 
@@ -174,23 +251,79 @@ func convertToStringArray(interfaceArray []interface{}) []string {
 	return stringArray
 }
 
-func appendContentFromDetailVirtualServerApi(contents []map[string]interface{}, ctx context.Context, inst *client.Instance, virtualServerId string) ([]map[string]interface{}, diag.Diagnostics, bool) {
-	content, err := getContentFromDetailVirtualServerApi(ctx, inst, virtualServerId)
-	if err != nil {
-		return nil, diag.FromErr(err), true
-	}
-	contents = append(contents, content)
-	return contents, nil, false
+func getContentFromDetailVirtualServerApi(ctx context.Context, inst *client.Instance, virtualServerId string) (map[string]interface{}, error) {
+	content, _, err := getFilteredContentFromDetailVirtualServerApi(ctx, inst, virtualServerId, virtualServerListFilter{})
+	return content, err
 }
 
-func getContentFromDetailVirtualServerApi(ctx context.Context, inst *client.Instance, virtualServerId string) (map[string]interface{}, error) {
+// getFilteredContentFromDetailVirtualServerApi reads the virtual server with its network interfaces, block storages and tags,
+// and checks the filter. The filter is checked after each call so that unmatched servers skip the remaining calls.
+func getFilteredContentFromDetailVirtualServerApi(ctx context.Context, inst *client.Instance, virtualServerId string, filter virtualServerListFilter) (map[string]interface{}, bool, error) {
 	detailResponse, err := inst.Client.VirtualServer.DetailVirtualServer(ctx, virtualServerId)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	target := virtualServerFilterTarget{
+		VirtualServerState: detailResponse.VirtualServerState,
+		VpcId:              detailResponse.VpcId,
+		Tags:               make(map[string]string),
+	}
+	for _, securityGroup := range detailResponse.SecurityGroupIds {
+		target.SecurityGroupIds = append(target.SecurityGroupIds, securityGroup.SecurityGroupId)
+	}
+	if !filter.matchesServer(target) {
+		return nil, false, nil
 	}
+
+	nicList, err := inst.Client.VirtualServer.GetNicList(ctx, virtualServerId)
+	if err != nil {
+		return nil, false, err
+	}
+	nics := make([]map[string]interface{}, 0)
+	for _, nic := range nicList.Contents {
+		target.SubnetIds = append(target.SubnetIds, nic.SubnetId)
+		nics = append(nics, map[string]interface{}{
+			"nic_id":    nic.NicId,
+			"subnet_id": nic.SubnetId,
+			"ip":        nic.Ip,
+			"nat_ip":    nic.NatIp,
+		})
+	}
+
+	if !filter.matchesSubnets(target) {
+		return nil, false, nil
+	}
+
+	tagList, _, err := inst.Client.Tag.ListResourceTags(ctx, virtualServerId)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, tag := range tagList.Contents {
+		target.Tags[tag.TagKey] = tag.TagValue
+	}
+
+	if !filter.matchesTags(target) {
+		return nil, false, nil
+	}
+
+	blockStorages := make([]map[string]interface{}, 0)
+	for _, blockStorage := range getBlockStorageResponseList(ctx, detailResponse.BlockStorageIds, inst) {
+		blockStorages = append(blockStorages, map[string]interface{}{
+			"block_storage_id":   blockStorage.BlockStorageId,
+			"block_storage_name": blockStorage.BlockStorageName,
+			"block_storage_size": blockStorage.BlockStorageSize,
+			"is_boot_disk":       *blockStorage.IsBootDisk,
+			"shared_type":        blockStorage.SharedType,
+		})
+	}
+
 	content := getContentMap(detailResponse)
 	validContentMap := getContentMapMatchedWithSchemaAttr(content)
-	return validContentMap, err
+	validContentMap["nics"] = nics
+	validContentMap["block_storages"] = blockStorages
+	validContentMap["tags"] = target.Tags
+	return validContentMap, true, nil
 }
 
 func getContentMapMatchedWithSchemaAttr(content map[string]interface{}) map[string]interface{} {
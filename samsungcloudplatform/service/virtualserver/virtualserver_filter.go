@@ -0,0 +1,82 @@
+package virtualserver
+
+// virtualServerListFilter has the filters of samsungcloudplatform_virtual_servers which the list API does not support
+type virtualServerListFilter struct {
+	VirtualServerState string
+	VpcId              string
+	SubnetId           string
+	SecurityGroupId    string
+	Tags               map[string]string
+}
+
+type virtualServerFilterTarget struct {
+	VirtualServerState string
+	VpcId              string
+	SubnetIds          []string
+	SecurityGroupIds   []string
+	Tags               map[string]string
+}
+
+// isEmpty reports whether the filter has no values, so that every virtual server matches
+func (filter virtualServerListFilter) isEmpty() bool {
+	return len(filter.VirtualServerState) == 0 && len(filter.VpcId) == 0 && len(filter.SubnetId) == 0 &&
+		len(filter.SecurityGroupId) == 0 && len(filter.Tags) == 0
+}
+
+// matches checks that the target has all the values of the filter.
+// Empty values of the filter match anything, and a tag with an empty value matches any value of the tag key.
+func (filter virtualServerListFilter) matches(target virtualServerFilterTarget) bool {
+	return filter.matchesServer(target) && filter.matchesSubnets(target) && filter.matchesTags(target)
+}
+
+// matchesServer checks the values which the detail API of the virtual server has
+func (filter virtualServerListFilter) matchesServer(target virtualServerFilterTarget) bool {
+	if len(filter.VirtualServerState) != 0 && filter.VirtualServerState != target.VirtualServerState {
+		return false
+	}
+	if len(filter.VpcId) != 0 && filter.VpcId != target.VpcId {
+		return false
+	}
+	if len(filter.SecurityGroupId) != 0 && !containsString(target.SecurityGroupIds, filter.SecurityGroupId) {
+		return false
+	}
+	return true
+}
+
+// matchesSubnets checks the subnet of the network interfaces
+func (filter virtualServerListFilter) matchesSubnets(target virtualServerFilterTarget) bool {
+	return len(filter.SubnetId) == 0 || containsString(target.SubnetIds, filter.SubnetId)
+}
+
+// matchesTags checks the tags
+func (filter virtualServerListFilter) matchesTags(target virtualServerFilterTarget) bool {
+	for key, value := range filter.Tags {
+		if tagValue, ok := target.Tags[key]; !ok || (len(value) != 0 && tagValue != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesListItem checks the state and the vpc with the item of the list API so that servers which can not match
+// are skipped without calling the detail API. Values missing from the item are left to the detail check.
+func (filter virtualServerListFilter) matchesListItem(item map[string]interface{}) bool {
+	if state, ok := item["virtual_server_state"].(string); ok && len(state) != 0 &&
+		len(filter.VirtualServerState) != 0 && filter.VirtualServerState != state {
+		return false
+	}
+	if vpcId, ok := item["vpc_id"].(string); ok && len(vpcId) != 0 &&
+		len(filter.VpcId) != 0 && filter.VpcId != vpcId {
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package virtualserver
+
+import "testing"
+
+func TestVirtualServerListFilterMatches(t *testing.T) {
+	target := virtualServerFilterTarget{
+		VirtualServerState: "RUNNING",
+		VpcId:              "VPC-1",
+		SubnetIds:          []string{"SUBNET-1", "SUBNET-2"},
+		SecurityGroupIds:   []string{"FIREWALL_SECURITY_GROUP-1"},
+		Tags:               map[string]string{"env": "prod", "team": "infra"},
+	}
+
+	tests := []struct {
+		name     string
+		filter   virtualServerListFilter
+		expected bool
+	}{
+		{"empty", virtualServerListFilter{}, true},
+		{"state", virtualServerListFilter{VirtualServerState: "STOPPED"}, false},
+		{"vpc", virtualServerListFilter{VpcId: "VPC-1"}, true},
+		{"local subnet", virtualServerListFilter{SubnetId: "SUBNET-2"}, true},
+		{"other subnet", virtualServerListFilter{SubnetId: "SUBNET-3"}, false},
+		{"security group", virtualServerListFilter{SecurityGroupId: "FIREWALL_SECURITY_GROUP-2"}, false},
+		{"tags", virtualServerListFilter{Tags: map[string]string{"env": "prod", "team": ""}}, true},
+		{"tag value", virtualServerListFilter{Tags: map[string]string{"env": "dev"}}, false},
+		{"tag key", virtualServerListFilter{Tags: map[string]string{"owner": ""}}, false},
+	}
+	for _, test := range tests {
+		if matched := test.filter.matches(target); matched != test.expected {
+			t.Errorf("%s : expected %v, got %v", test.name, test.expected, matched)
+		}
+	}
+}
+
+func TestVirtualServerListFilterMatchesListItem(t *testing.T) {
+	filter := virtualServerListFilter{VirtualServerState: "RUNNING", VpcId: "VPC-1"}
+
+	tests := []struct {
+		name     string
+		item     map[string]interface{}
+		expected bool
+	}{
+		{"matched", map[string]interface{}{"virtual_server_state": "RUNNING", "vpc_id": "VPC-1"}, true},
+		{"state", map[string]interface{}{"virtual_server_state": "STOPPED", "vpc_id": "VPC-1"}, false},
+		{"vpc", map[string]interface{}{"virtual_server_state": "RUNNING", "vpc_id": "VPC-2"}, false},
+		{"missing values", map[string]interface{}{}, true},
+	}
+	for _, test := range tests {
+		if matched := filter.matchesListItem(test.item); matched != test.expected {
+			t.Errorf("%s : expected %v, got %v", test.name, test.expected, matched)
+		}
+	}
+
+	if !(virtualServerListFilter{Tags: map[string]string{}}).isEmpty() {
+		t.Errorf("filter with empty tags should be empty")
+	}
+	if (virtualServerListFilter{SubnetId: "SUBNET-1"}).isEmpty() {
+		t.Errorf("filter with subnet should not be empty")
+	}
+}
@@ -0,0 +1,181 @@
+package virtualserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_virtual_server_nic", ResourceVirtualServerNic())
+}
+
+func ResourceVirtualServerNic() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVirtualServerNicCreate,
+		ReadContext:   resourceVirtualServerNicRead,
+		DeleteContext: resourceVirtualServerNicDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVirtualServerNicImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"virtual_server_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Virtual server id to attach the network interface",
+			},
+			"subnet_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Local subnet id of the network interface",
+			},
+			"ipv4": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: common.ValidateIpv4WithEmptyValue,
+				Description:      "IP address of the network interface. Assigned automatically if not specified.",
+			},
+			"subnet_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Subnet type of the network interface",
+			},
+		},
+		Description: "Provides a secondary network interface of a virtual server attached to a local subnet.",
+	}
+}
+
+func resourceVirtualServerNicCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	virtualServerId := rd.Get("virtual_server_id").(string)
+	subnetId := rd.Get("subnet_id").(string)
+	ipv4 := rd.Get("ipv4").(string)
+
+	if len(ipv4) != 0 {
+		res, err := inst.Client.Subnet.CheckAvailableSubnetIp(ctx, subnetId, ipv4)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if *res.Result == false {
+			return diag.Errorf("Not Available Local Subnet Ip Address")
+		}
+	}
+
+	err := WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Remember existing network interfaces to find the attached one
+	nicInfoList, err := inst.Client.VirtualServer.GetNicList(ctx, virtualServerId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	existingNicIds := make(map[string]bool)
+	for _, nic := range nicInfoList.Contents {
+		existingNicIds[nic.NicId] = true
+	}
+
+	_, _, err = inst.Client.VirtualServer.AttachLocalSubnet(ctx, virtualServerId, subnetId, ipv4)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nicInfoList, err = inst.Client.VirtualServer.GetNicList(ctx, virtualServerId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, nic := range nicInfoList.Contents {
+		if existingNicIds[nic.NicId] || nic.SubnetId != subnetId {
+			continue
+		}
+		if len(ipv4) != 0 && nic.Ip != ipv4 {
+			continue
+		}
+		rd.SetId(nic.NicId)
+		break
+	}
+
+	if len(rd.Id()) == 0 {
+		return diag.Errorf("attached local subnet network interface not found")
+	}
+
+	return resourceVirtualServerNicRead(ctx, rd, meta)
+}
+
+func resourceVirtualServerNicRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	nicInfoList, err := inst.Client.VirtualServer.GetNicList(ctx, rd.Get("virtual_server_id").(string))
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	for _, nic := range nicInfoList.Contents {
+		if nic.NicId == rd.Id() {
+			rd.Set("subnet_id", nic.SubnetId)
+			rd.Set("ipv4", nic.Ip)
+			rd.Set("subnet_type", nic.SubnetType)
+			return nil
+		}
+	}
+
+	rd.SetId("")
+	return nil
+}
+
+func resourceVirtualServerNicDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	virtualServerId := rd.Get("virtual_server_id").(string)
+
+	err := WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, _, err = inst.Client.VirtualServer.DetachLocalSubnet(ctx, virtualServerId, rd.Id())
+	if err != nil && !common.IsDeleted(err) {
+		return diag.FromErr(err)
+	}
+
+	err = WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState, common.DeletedState}, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceVirtualServerNicImport imports with "<virtual_server_id>/<nic_id>" format
+func resourceVirtualServerNicImport(ctx context.Context, rd *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.Split(rd.Id(), "/")
+	if len(ids) != 2 || len(ids[0]) == 0 || len(ids[1]) == 0 {
+		return nil, fmt.Errorf("invalid import id %q. expected format is <virtual_server_id>/<nic_id>", rd.Id())
+	}
+
+	rd.Set("virtual_server_id", ids[0])
+	rd.SetId(ids[1])
+
+	return []*schema.ResourceData{rd}, nil
+}
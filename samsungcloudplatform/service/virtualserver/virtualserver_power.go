@@ -0,0 +1,135 @@
+package virtualserver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/client"
+	"github.com/SamsungSDSCloud/terraform-provider-samsungcloudplatform/v3/samsungcloudplatform/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func init() {
+	samsungcloudplatform.RegisterResource("samsungcloudplatform_virtual_server_power", ResourceVirtualServerPower())
+}
+
+func ResourceVirtualServerPower() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVirtualServerPowerCreate,
+		ReadContext:   resourceVirtualServerPowerRead,
+		UpdateContext: resourceVirtualServerPowerUpdate,
+		DeleteContext: resourceVirtualServerPowerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVirtualServerPowerImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Update: schema.DefaultTimeout(15 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"virtual_server_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Virtual server id",
+			},
+			"state": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: common.ValidateThatVmStateOnlyHasRunningOrStopped,
+				Description:      "Power state of the virtual server (RUNNING or STOPPED)",
+			},
+		},
+		Description: "Provides the power state of a virtual server. The virtual server is not changed on destroy.",
+	}
+}
+
+// changeVirtualServerPowerState starts or stops the virtual server, and waits until it reaches the state
+func changeVirtualServerPowerState(ctx context.Context, inst *client.Instance, virtualServerId string, state string) error {
+	err := WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{common.RunningState, common.StoppedState}, true)
+	if err != nil {
+		return err
+	}
+
+	virtualServerInfo, _, err := inst.Client.VirtualServer.GetVirtualServer(ctx, virtualServerId)
+	if err != nil {
+		return err
+	}
+
+	state = strings.ToUpper(state)
+	if virtualServerInfo.VirtualServerState == state {
+		return nil
+	}
+
+	if state == common.StoppedState {
+		_, err = inst.Client.VirtualServer.StopVirtualServer(ctx, virtualServerId)
+	} else {
+		_, err = inst.Client.VirtualServer.StartVirtualServer(ctx, virtualServerId)
+	}
+	if err != nil {
+		return err
+	}
+
+	return WaitForVirtualServerStatus(ctx, inst.Client, virtualServerId, common.VirtualServerProcessingStates(), []string{state}, true)
+}
+
+func resourceVirtualServerPowerCreate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	virtualServerId := rd.Get("virtual_server_id").(string)
+	err := changeVirtualServerPowerState(ctx, inst, virtualServerId, rd.Get("state").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rd.SetId(virtualServerId)
+
+	return resourceVirtualServerPowerRead(ctx, rd, meta)
+}
+
+func resourceVirtualServerPowerRead(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	virtualServerInfo, _, err := inst.Client.VirtualServer.GetVirtualServer(ctx, rd.Id())
+	if err != nil {
+		rd.SetId("")
+		if common.IsDeleted(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	rd.Set("virtual_server_id", virtualServerInfo.VirtualServerId)
+	// keep the declared state while the virtual server is processing
+	if virtualServerInfo.VirtualServerState == common.RunningState || virtualServerInfo.VirtualServerState == common.StoppedState {
+		rd.Set("state", virtualServerInfo.VirtualServerState)
+	}
+
+	return nil
+}
+
+func resourceVirtualServerPowerUpdate(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	inst := meta.(*client.Instance)
+
+	if rd.HasChanges("state") {
+		err := changeVirtualServerPowerState(ctx, inst, rd.Id(), rd.Get("state").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceVirtualServerPowerRead(ctx, rd, meta)
+}
+
+func resourceVirtualServerPowerDelete(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// the virtual server keeps its power state
+	return nil
+}
+
+func resourceVirtualServerPowerImport(ctx context.Context, rd *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	rd.Set("virtual_server_id", rd.Id())
+	return []*schema.ResourceData{rd}, nil
+}